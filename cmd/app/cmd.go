@@ -10,8 +10,12 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/gardener/docforge/cmd/docusaurus"
+	"github.com/gardener/docforge/cmd/format"
 	"github.com/gardener/docforge/cmd/gendocs"
+	"github.com/gardener/docforge/cmd/html"
 	"github.com/gardener/docforge/cmd/hugo"
+	"github.com/gardener/docforge/cmd/mkdocs"
 	"github.com/gardener/docforge/cmd/version"
 	"github.com/gardener/docforge/pkg/manifest"
 	"github.com/gardener/docforge/pkg/readers/repositoryhosts"
@@ -31,6 +35,9 @@ const (
 type options struct {
 	Options                               `mapstructure:",squash"`
 	hugo.Hugo                             `mapstructure:",squash"`
+	mkdocs.MkDocs                         `mapstructure:",squash"`
+	docusaurus.Docusaurus                 `mapstructure:",squash"`
+	html.HTML                             `mapstructure:",squash"`
 	repositoryhosts.RepositoryHostOptions `mapstructure:",squash"`
 	manifest.ParsingOptions               `mapstructure:",squash"`
 }
@@ -56,6 +63,15 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	genCmdDocs := gendocs.NewGenCmdDocs()
 	cmd.AddCommand(genCmdDocs)
 
+	resolveCmd := newResolveCmd(ctx)
+	cmd.AddCommand(resolveCmd)
+
+	diffManifestCmd := newDiffManifestCmd(ctx)
+	cmd.AddCommand(diffManifestCmd)
+
+	formatCmd := format.NewFormatCmd()
+	cmd.AddCommand(formatCmd)
+
 	klog.InitFlags(nil)
 	addFlags(cmd)
 
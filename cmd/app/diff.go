@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/spf13/cobra"
+)
+
+// diff output formats
+const (
+	diffOutputText = "text"
+	diffOutputJSON = "json"
+)
+
+// newDiffManifestCmd creates a command reporting the output changes between two manifests
+func newDiffManifestCmd(ctx context.Context) *cobra.Command {
+	var output string
+	command := &cobra.Command{
+		Use:   "diff-manifest <old manifest> <new manifest>",
+		Short: "Report the output files added, removed, moved or changed between two manifests",
+		Long: "Resolves both manifests and compares their output files. Files are matched by source URL, " +
+			"so a file written to a different path is reported as moved. Files keeping their path with different " +
+			"sources are reported as source changes and changes of the front matter `title` as retitled.",
+		Args: cobra.ExactArgs(2),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			bindFlags(cmd, resolveFlags...)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			return diffManifests(ctx, args[0], args[1], output, os.Stdout)
+		},
+	}
+	configureResolveFlags(command)
+	command.Flags().StringVarP(&output, "output", "o", diffOutputText,
+		"Specifies the output format. Must be one of: text or json.")
+	return command
+}
+
+func diffManifests(ctx context.Context, oldURL string, newURL string, output string, w io.Writer) error {
+	if output != diffOutputText && output != diffOutputJSON {
+		return fmt.Errorf("unknown output format %q. Must be one of: %s or %s", output, diffOutputText, diffOutputJSON)
+	}
+	registry, options, err := initRegistry(ctx)
+	if err != nil {
+		return err
+	}
+	oldNodes, err := manifest.ResolveManifestWithOptions(oldURL, registry, options.ParsingOptions)
+	if err != nil {
+		return fmt.Errorf("failed to resolve manifest %s. %+v", oldURL, err)
+	}
+	newNodes, err := manifest.ResolveManifestWithOptions(newURL, registry, options.ParsingOptions)
+	if err != nil {
+		return fmt.Errorf("failed to resolve manifest %s. %+v", newURL, err)
+	}
+	diff := manifest.DiffManifests(oldNodes, newNodes)
+	if output == diffOutputJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(diff)
+	}
+	return diff.WriteText(w)
+}
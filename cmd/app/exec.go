@@ -5,17 +5,33 @@
 package app
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"os"
+	"path"
 	"sync"
 
 	"github.com/gardener/docforge/pkg/manifest"
 	"github.com/gardener/docforge/pkg/readers/repositoryhosts"
+	"github.com/gardener/docforge/pkg/redirects"
+	"github.com/gardener/docforge/pkg/workers/anchorvalidator"
 	documentworker "github.com/gardener/docforge/pkg/workers/document"
+	"github.com/gardener/docforge/pkg/workers/document/markdown"
+	"github.com/gardener/docforge/pkg/workers/document/transformer"
+	"github.com/gardener/docforge/pkg/workers/docusaurussidebars"
 	"github.com/gardener/docforge/pkg/workers/downloader"
 	"github.com/gardener/docforge/pkg/workers/githubinfo"
+	"github.com/gardener/docforge/pkg/workers/htmlsite"
+	"github.com/gardener/docforge/pkg/workers/linkresolver"
 	"github.com/gardener/docforge/pkg/workers/linkvalidator"
+	"github.com/gardener/docforge/pkg/workers/mkdocsnav"
+	"github.com/gardener/docforge/pkg/workers/searchindex"
+	"github.com/gardener/docforge/pkg/workers/sectionindex"
+	"github.com/gardener/docforge/pkg/workers/singledocument"
 	"github.com/gardener/docforge/pkg/workers/taskqueue"
+	"github.com/gardener/docforge/pkg/writers"
+	"github.com/hashicorp/go-multierror"
 	"k8s.io/klog/v2"
 )
 
@@ -34,11 +50,17 @@ func exec(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if countEnabled(options.Hugo.Enabled, options.MkDocs.Enabled, options.Docusaurus.Enabled, options.HTML.Enabled) > 1 {
+		return fmt.Errorf("only one of --hugo, --mkdocs, --docusaurus and --html can be enabled")
+	}
+	if options.SingleDocument != "" && (options.MkDocs.Enabled || options.Docusaurus.Enabled || options.HTML.Enabled) {
+		return fmt.Errorf("--single-document can't be used with --mkdocs, --docusaurus or --html")
+	}
 	if rhs, err = initRepositoryHosts(ctx, options.RepositoryHostOptions, options.ParsingOptions); err != nil {
 		return err
 	}
 
-	config := getReactorConfig(options.Options, options.Hugo, rhs)
+	config := getReactorConfig(options.Options, options.Hugo, options.MkDocs, options.Docusaurus, options.HTML, rhs)
 	manifestURL := options.ManifestPath
 	var (
 		ghInfo      githubinfo.GitHubInfo
@@ -47,15 +69,39 @@ func exec(ctx context.Context) error {
 	reactorWG := &sync.WaitGroup{}
 
 	rhRegistry := repositoryhosts.NewRegistry(config.RepositoryHosts...)
-	documentNodes, err := manifest.ResolveManifest(manifestURL, rhRegistry)
+	documentNodes, err := manifest.ResolveManifestWithOptions(manifestURL, rhRegistry, options.ParsingOptions)
 	if err != nil {
 		return fmt.Errorf("failed to resolve manifest %s. %+v", config.ManifestPath, err)
 	}
+	if config.MkDocs.Enabled || config.HTML.Enabled {
+		mkdocsnav.RenameIndexes(documentNodes[0], config.IndexFileNames)
+	}
 	if config.Resolve {
-		fmt.Println(documentNodes[0])
+		if err = writeResolved(os.Stdout, resolveOutputYAML, documentNodes[0]); err != nil {
+			return err
+		}
+	}
+	if config.TOC {
+		if err = applyTOC(config, documentNodes); err != nil {
+			return err
+		}
 	}
 
-	dScheduler, downloadTasks, err := downloader.New(config.ResourceDownloadWorkersCount, config.FailFast, reactorWG, rhRegistry, config.ResourceDownloadWriter)
+	var shortcodes markdown.ShortcodeParams
+	if config.Hugo.Enabled {
+		if shortcodes, err = markdown.ParseShortcodeParams(config.Hugo.ShortcodeLinks); err != nil {
+			return err
+		}
+	}
+
+	downloadWriter := config.ResourceDownloadWriter
+	var resources *singledocument.Resources
+	if config.SingleDocument != "" && config.SingleDocumentImages == singledocument.InlineImages {
+		// the single document inlines the images as they are downloaded
+		resources = singledocument.NewResources(downloadWriter)
+		downloadWriter = resources
+	}
+	dScheduler, downloadTasks, err := downloader.New(config.ResourceDownloadWorkersCount, config.FailFast, reactorWG, rhRegistry, downloadWriter)
 	if err != nil {
 		return err
 	}
@@ -66,7 +112,64 @@ func exec(ctx context.Context) error {
 	if !config.ValidateLinks {
 		v = nil
 	}
-	docProcessor, docTasks, err := documentworker.New(config.DocumentWorkersCount, config.FailFast, reactorWG, documentNodes, config.ResourcesPath, dScheduler, v, rhRegistry, config.Hugo, config.Writer)
+	var anchors anchorvalidator.Interface
+	if config.ValidateAnchors {
+		anchors = anchorvalidator.New()
+	}
+	writer := config.Writer
+	var nav *mkdocsnav.Writer
+	if config.MkDocs.Enabled {
+		if nav, err = newMkDocsNavWriter(writer, config); err != nil {
+			return err
+		}
+		writer = nav
+	}
+	var sidebars *docusaurussidebars.Writer
+	if config.Docusaurus.Enabled {
+		if sidebars, err = docusaurussidebars.New(writer, config.SiteConfigWriter, config.Docusaurus.SidebarsFormat, config.IndexFileNames); err != nil {
+			return err
+		}
+		writer = sidebars
+	}
+	var single *singledocument.Writer
+	if config.SingleDocument != "" {
+		if single, err = newSingleDocumentWriter(writer, config, resources); err != nil {
+			return err
+		}
+		writer = single
+	}
+	var search searchindex.Interface
+	if config.SearchIndexFormat != "" {
+		if err = searchindex.Validate(config.SearchIndexFormat); err != nil {
+			return err
+		}
+		if search, err = searchindex.New(config.SearchIndexFields, config.SearchIndexExcludeSections); err != nil {
+			return err
+		}
+	}
+	var site *htmlsite.Writer
+	if config.HTML.Enabled {
+		if site, err = newHTMLSiteWriter(writer, config); err != nil {
+			return err
+		}
+		writer = site
+	}
+	links := &linkresolver.LinkResolver{Hugo: config.Hugo, MkDocs: config.MkDocs, Docusaurus: config.Docusaurus, HTML: config.HTML}
+	var sectionIndex *sectionindex.Writer
+	if config.SectionIndex {
+		if sectionIndex, err = newSectionIndexWriter(writer, config, links); err != nil {
+			return err
+		}
+		writer = sectionIndex
+	}
+	var redirectsWriter *redirects.Writer
+	if config.RedirectsFormat != "" {
+		if redirectsWriter, err = redirects.New(writer, config.SiteConfigWriter, config.RedirectsFormat, config.RedirectsFile, links); err != nil {
+			return err
+		}
+		writer = redirectsWriter
+	}
+	docProcessor, docTasks, err := documentworker.New(config.DocumentWorkersCount, config.FailFast, reactorWG, documentNodes, config.ResourcesPath, dScheduler, v, anchors, search, rhRegistry, documentworker.Options{Hugo: config.Hugo, MkDocs: config.MkDocs, Docusaurus: config.Docusaurus, HTML: config.HTML, ShortcodeParams: shortcodes}, writer)
 	if err != nil {
 		return err
 	}
@@ -100,5 +203,136 @@ func exec(ctx context.Context) error {
 	qcc.Stop()
 	qcc.LogTaskProcessed()
 	rhRegistry.LogRateLimits(ctx)
-	return qcc.GetErrorList().ErrorOrNil()
+	errs := qcc.GetErrorList()
+	if anchors != nil {
+		problems := anchors.Validate()
+		for _, problem := range problems {
+			klog.Warning(problem)
+		}
+		if config.FailFast && len(problems) > 0 {
+			errs = multierror.Append(errs, fmt.Errorf("%d broken anchors", len(problems)))
+		}
+	}
+	if search != nil {
+		errs = multierror.Append(errs, writeSearchIndex(config, search))
+	}
+	if sectionIndex != nil {
+		errs = multierror.Append(errs, sectionIndex.Generate(documentNodes[0]))
+	}
+	if nav != nil {
+		errs = multierror.Append(errs, nav.Generate(documentNodes[0]))
+	}
+	if sidebars != nil {
+		errs = multierror.Append(errs, sidebars.Generate(documentNodes[0]))
+	}
+	if single != nil {
+		errs = multierror.Append(errs, single.Generate(documentNodes[0]))
+	}
+	if site != nil {
+		errs = multierror.Append(errs, site.Generate(documentNodes[0]))
+	}
+	if redirectsWriter != nil {
+		problems, err := redirectsWriter.Generate(documentNodes)
+		for _, problem := range problems {
+			klog.Warning(problem)
+		}
+		errs = multierror.Append(errs, err)
+	}
+	return errs.ErrorOrNil()
+}
+
+// applyTOC enables the table of contents transformer with the levels of the flags for the nodes that don't configure it
+func applyTOC(config Config, nodes []*manifest.Node) error {
+	if config.TOCMinLevel < 1 || config.TOCMaxLevel > 6 || config.TOCMinLevel > config.TOCMaxLevel {
+		return fmt.Errorf("invalid table of contents levels %d-%d", config.TOCMinLevel, config.TOCMaxLevel)
+	}
+	for _, node := range nodes {
+		if _, ok := node.Transformers[transformer.TOCName]; ok {
+			continue
+		}
+		transformers := map[string]interface{}{
+			transformer.TOCName: map[string]interface{}{transformer.TOCMinLevel: config.TOCMinLevel, transformer.TOCMaxLevel: config.TOCMaxLevel},
+		}
+		for k, v := range node.Transformers {
+			transformers[k] = v
+		}
+		node.Transformers = transformers
+	}
+	return nil
+}
+
+// writeSearchIndex writes the search index of the processed documents
+func writeSearchIndex(config Config, search searchindex.Interface) error {
+	var b bytes.Buffer
+	if err := search.Write(&b, config.SearchIndexFormat); err != nil {
+		return err
+	}
+	file := config.SearchIndexFile
+	if file == "" {
+		file = searchindex.DefaultFileName
+	}
+	return config.Writer.Write(path.Base(file), path.Dir(file), b.Bytes(), nil)
+}
+
+// newSectionIndexWriter creates the section index writer decorating writer
+func newSectionIndexWriter(writer writers.Writer, config Config, links *linkresolver.LinkResolver) (*sectionindex.Writer, error) {
+	indexTemplate := sectionindex.DefaultTemplate
+	if config.SectionIndexTemplate != "" {
+		content, err := os.ReadFile(config.SectionIndexTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read section index template %s: %w", config.SectionIndexTemplate, err)
+		}
+		indexTemplate = string(content)
+	}
+	return sectionindex.New(writer, indexTemplate, config.SectionIndexAppend, links)
+}
+
+// newMkDocsNavWriter creates the MkDocs nav writer decorating writer
+func newMkDocsNavWriter(writer writers.Writer, config Config) (*mkdocsnav.Writer, error) {
+	var baseConfig []byte
+	if config.MkDocs.Config != "" {
+		content, err := os.ReadFile(config.MkDocs.Config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read MkDocs configuration %s: %w", config.MkDocs.Config, err)
+		}
+		baseConfig = content
+	}
+	return mkdocsnav.New(writer, config.SiteConfigWriter, baseConfig), nil
+}
+
+// newSingleDocumentWriter creates the single document writer decorating writer, which inlines the
+// images held back by resources if it is not nil
+func newSingleDocumentWriter(writer writers.Writer, config Config, resources *singledocument.Resources) (*singledocument.Writer, error) {
+	if err := singledocument.ValidateImages(config.SingleDocumentImages); err != nil {
+		return nil, err
+	}
+	var readResource singledocument.ReadResource
+	if resources != nil {
+		readResource = resources.Read
+	}
+	return singledocument.New(writer, config.SingleDocument, config.ResourcesPath, config.Hugo, readResource), nil
+}
+
+// newHTMLSiteWriter creates the HTML site writer decorating writer
+func newHTMLSiteWriter(writer writers.Writer, config Config) (*htmlsite.Writer, error) {
+	layout := htmlsite.DefaultTemplate
+	if config.HTML.Template != "" {
+		content, err := os.ReadFile(config.HTML.Template)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read HTML template %s: %w", config.HTML.Template, err)
+		}
+		layout = string(content)
+	}
+	return htmlsite.New(writer, layout)
+}
+
+// countEnabled returns the number of enabled options
+func countEnabled(enabled ...bool) int {
+	count := 0
+	for _, e := range enabled {
+		if e {
+			count++
+		}
+	}
+	return count
 }
@@ -8,26 +8,25 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/gardener/docforge/pkg/workers/document/markdown"
+	"github.com/gardener/docforge/pkg/workers/searchindex"
+	"github.com/gardener/docforge/pkg/workers/singledocument"
 	"github.com/spf13/cobra"
 )
 
 func configureFlags(command *cobra.Command) {
+	configureManifestPathFlag(command)
+	configureResolveFlags(command)
+	bindFlags(command, append(resolveFlags, "manifest")...)
+
 	command.Flags().StringP("destination", "d", "",
 		"Destination path.")
 	_ = vip.BindPFlag("destination", command.Flags().Lookup("destination"))
 
-	command.Flags().StringP("manifest", "f", "",
-		"Manifest path.")
-	_ = vip.BindPFlag("manifest", command.Flags().Lookup("manifest"))
-
 	command.Flags().String("resources-download-path", "__resources",
 		"Resources download path.")
 	_ = vip.BindPFlag("resources-download-path", command.Flags().Lookup("resources-download-path"))
 
-	command.Flags().StringToString("github-oauth-token-map", map[string]string{},
-		"GitHub personal tokens authorizing read access from repositories per GitHub instance. Note that if the GitHub token is already provided by `github-oauth-token` it will be overridden by it.")
-	_ = vip.BindPFlag("github-oauth-token-map", command.Flags().Lookup("github-oauth-token-map"))
-
 	command.Flags().String("github-info-destination", "",
 		"If specified, docforge will download also additional github info for the files from the documentation structure into this destination.")
 	_ = vip.BindPFlag("github-info-destination", command.Flags().Lookup("github-info-destination"))
@@ -41,7 +40,7 @@ func configureFlags(command *cobra.Command) {
 	_ = vip.BindPFlag("dry-run", command.Flags().Lookup("dry-run"))
 
 	command.Flags().Bool("resolve", false,
-		"Resolves the documentation structure and prints it to the standard output. The resolution expands nodeSelector constructs into node hierarchies.")
+		"Resolves the documentation structure and prints it to the standard output as the resolve command with --output yaml. The resolution expands nodeSelector constructs into node hierarchies.")
 	_ = vip.BindPFlag("resolve", command.Flags().Lookup("resolve"))
 
 	command.Flags().Int("document-workers", 25,
@@ -69,17 +68,125 @@ func configureFlags(command *cobra.Command) {
 	_ = vip.BindPFlag("hugo-base-url", command.Flags().Lookup("hugo-base-url"))
 
 	command.Flags().StringSlice("hugo-section-files", []string{"readme.md", "readme", "read.me", "index.md", "index"},
-		"When building a Hugo-compliant documentation bundle, files with filename matching one form this list (in that order) will be renamed to _index.md. Only useful with --hugo=true")
+		"Files with a name matching one from this list (in that order) are the index files of their folder. Hugo renames them to _index.md, and --mkdocs=true and --html=true rename them to index.md")
 	_ = vip.BindPFlag("hugo-section-files", command.Flags().Lookup("hugo-section-files"))
 
-	command.Flags().StringSlice("extracted-files-formats", []string{".md"},
-		"Supported content format extensions (exampel: .md)")
-	_ = vip.BindPFlag("extracted-files-formats", command.Flags().Lookup("extracted-files-formats"))
+	command.Flags().Bool("hugo-title-from-h1", false,
+		"Uses the first H1 of a document as its title when the front matter has none, instead of the file name. Only useful with --hugo=true")
+	_ = vip.BindPFlag("hugo-title-from-h1", command.Flags().Lookup("hugo-title-from-h1"))
+
+	command.Flags().Bool("hugo-remove-title-h1", false,
+		"Removes the first H1 from the document body when it is used as title. Only useful with --hugo-title-from-h1=true")
+	_ = vip.BindPFlag("hugo-remove-title-h1", command.Flags().Lookup("hugo-remove-title-h1"))
+
+	command.Flags().StringSlice("hugo-shortcode-links", markdown.DefaultShortcodeLinks,
+		"Shortcode parameters with links resolved like markdown links, as shortcode:parameter where parameter is a name or the position of an unnamed argument. Only useful with --hugo=true")
+	_ = vip.BindPFlag("hugo-shortcode-links", command.Flags().Lookup("hugo-shortcode-links"))
+
+	command.Flags().Bool("mkdocs", false,
+		"Build documentation bundle for MkDocs. The documents are written in the docs folder of the destination and the nav of its mkdocs.yml is generated from the structure")
+	_ = vip.BindPFlag("mkdocs", command.Flags().Lookup("mkdocs"))
+
+	command.Flags().String("mkdocs-config", "",
+		"MkDocs configuration file whose sections, except the nav, are kept in the generated mkdocs.yml. Only useful with --mkdocs=true")
+	_ = vip.BindPFlag("mkdocs-config", command.Flags().Lookup("mkdocs-config"))
+
+	command.Flags().Bool("docusaurus", false,
+		"Build documentation bundle for Docusaurus. The documents are written in the docs folder of the destination with MDX-safe content and id and sidebar_position front matter, and the sidebars are generated from the structure")
+	_ = vip.BindPFlag("docusaurus", command.Flags().Lookup("docusaurus"))
+
+	command.Flags().String("docusaurus-sidebars-format", "js",
+		"Format of the generated sidebars file. Must be one of: js or json. Only useful with --docusaurus=true")
+	_ = vip.BindPFlag("docusaurus-sidebars-format", command.Flags().Lookup("docusaurus-sidebars-format"))
+
+	command.Flags().Bool("html", false,
+		"Build documentation bundle as an HTML site. The documents are rendered as HTML pages in a layout with the navigation of the structure and link each other with relative links")
+	_ = vip.BindPFlag("html", command.Flags().Lookup("html"))
+
+	command.Flags().String("html-template", "",
+		"Path to a Go html/template file overriding the layout of the HTML site pages. Only useful with --html=true")
+	_ = vip.BindPFlag("html-template", command.Flags().Lookup("html-template"))
 
 	command.Flags().Bool("validate-links", true,
 		"Links should be validated")
 	_ = vip.BindPFlag("validate-links", command.Flags().Lookup("validate-links"))
 
+	command.Flags().Bool("validate-anchors", false,
+		"Opt-in validation of the fragment links to processed documents against the document headings and HTML anchors, enabled with --validate-anchors=true. Broken anchors are reported as warnings and fail the build with --fail-fast=true.")
+	_ = vip.BindPFlag("validate-anchors", command.Flags().Lookup("validate-anchors"))
+
+	command.Flags().Bool("section-index", false,
+		"Generates an index document listing the child pages of every dir node that has no index file.")
+	_ = vip.BindPFlag("section-index", command.Flags().Lookup("section-index"))
+
+	command.Flags().String("section-index-template", "",
+		"Path to a Go template file rendering the child pages list of section index documents. Only useful with --section-index=true")
+	_ = vip.BindPFlag("section-index-template", command.Flags().Lookup("section-index-template"))
+
+	command.Flags().Bool("section-index-append", false,
+		"Appends the child pages list to the existing index files, as defined by hugo-section-files. Only useful with --section-index=true")
+	_ = vip.BindPFlag("section-index-append", command.Flags().Lookup("section-index-append"))
+
+	command.Flags().String("redirects-format", "",
+		"Writes a redirect map from the aliases front matter of the nodes and their documents. Must be one of: netlify, nginx, apache or json.")
+	_ = vip.BindPFlag("redirects-format", command.Flags().Lookup("redirects-format"))
+
+	command.Flags().String("redirects-file", "",
+		"Path of the redirect map relative to the destination. Defaults to _redirects, redirects.map, .htaccess or redirects.json depending on the format. Only useful with --redirects-format")
+	_ = vip.BindPFlag("redirects-file", command.Flags().Lookup("redirects-file"))
+
+	command.Flags().Bool("toc", false,
+		"Enables the toc transformer generating a table of contents in every document, unless its node configures the toc transformer.")
+	_ = vip.BindPFlag("toc", command.Flags().Lookup("toc"))
+
+	command.Flags().Int("toc-min-level", 2,
+		"Level of the top headings listed in the generated tables of contents. Only useful with --toc=true")
+	_ = vip.BindPFlag("toc-min-level", command.Flags().Lookup("toc-min-level"))
+
+	command.Flags().Int("toc-max-level", 3,
+		"Level of the deepest headings listed in the generated tables of contents. Only useful with --toc=true")
+	_ = vip.BindPFlag("toc-max-level", command.Flags().Lookup("toc-max-level"))
+
+	command.Flags().String("single-document", "",
+		"Concatenates the documents of the structure in order into a single document with this name in the destination. The headings are shifted by the tree depth and the links to documents are rewritten to anchors in the single document")
+	_ = vip.BindPFlag("single-document", command.Flags().Lookup("single-document"))
+
+	command.Flags().String("single-document-images", singledocument.ReferenceImages,
+		"How the single document refers to images. Must be one of: reference, linking the downloaded resources, or inline, embedding them as data URIs. Only useful with --single-document")
+	_ = vip.BindPFlag("single-document-images", command.Flags().Lookup("single-document-images"))
+
+	command.Flags().String("search-index-format", "",
+		"Writes a client-side search index of the documents. Must be one of: lunr, the documents to add to a lunr.js index, or inverted, the documents and an index of their terms.")
+	_ = vip.BindPFlag("search-index-format", command.Flags().Lookup("search-index-format"))
+
+	command.Flags().String("search-index-file", searchindex.DefaultFileName,
+		"Path of the search index relative to the destination. Only useful with --search-index-format")
+	_ = vip.BindPFlag("search-index-file", command.Flags().Lookup("search-index-file"))
+
+	command.Flags().StringSlice("search-index-fields", searchindex.DefaultFields,
+		"Fields of the documents in the search index, in addition to their URL. Must be among: title, headings, body or tags. Only useful with --search-index-format")
+	_ = vip.BindPFlag("search-index-fields", command.Flags().Lookup("search-index-fields"))
+
+	command.Flags().StringSlice("search-index-exclude-sections", []string{},
+		"Headings of the document sections left out of the search index, compared case-insensitively. Only useful with --search-index-format")
+	_ = vip.BindPFlag("search-index-exclude-sections", command.Flags().Lookup("search-index-exclude-sections"))
+}
+
+// resolveFlags are the flags configuring the manifest resolution
+var resolveFlags = []string{"github-oauth-token-map", "extracted-files-formats", "cache-dir", "collision-strategy"}
+
+func configureManifestPathFlag(command *cobra.Command) {
+	command.Flags().StringP("manifest", "f", "",
+		"Manifest path.")
+}
+
+func configureResolveFlags(command *cobra.Command) {
+	command.Flags().StringToString("github-oauth-token-map", map[string]string{},
+		"GitHub personal tokens authorizing read access from repositories per GitHub instance. Note that if the GitHub token is already provided by `github-oauth-token` it will be overridden by it.")
+
+	command.Flags().StringSlice("extracted-files-formats", []string{".md"},
+		"Supported content format extensions (exampel: .md)")
+
 	cacheDir := ""
 	userHomeDir, err := os.UserHomeDir()
 	if err == nil {
@@ -88,5 +195,14 @@ func configureFlags(command *cobra.Command) {
 	}
 	command.Flags().String("cache-dir", cacheDir,
 		"Cache directory, used for repository cache.")
-	_ = vip.BindPFlag("cache-dir", command.Flags().Lookup("cache-dir"))
+
+	command.Flags().String("collision-strategy", "",
+		"Resolves files written to the same output path, overriding the root manifest collisionStrategy. Must be one of: error, first-wins, last-wins, suffix-with-repo or merge-as-multisource.")
+}
+
+// bindFlags binds the command flags to the configuration keys with the same names
+func bindFlags(command *cobra.Command, names ...string) {
+	for _, name := range names {
+		_ = vip.BindPFlag(name, command.Flags().Lookup(name))
+	}
 }
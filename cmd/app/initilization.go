@@ -13,11 +13,15 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/gardener/docforge/cmd/docusaurus"
+	"github.com/gardener/docforge/cmd/html"
 	"github.com/gardener/docforge/cmd/hugo"
+	"github.com/gardener/docforge/cmd/mkdocs"
 	"github.com/gardener/docforge/pkg/manifest"
 	"github.com/gardener/docforge/pkg/osfakes/osshim"
 	"github.com/gardener/docforge/pkg/readers/repositoryhosts"
 	"github.com/gardener/docforge/pkg/readers/repositoryhosts/githubhttpcache"
+	"github.com/gardener/docforge/pkg/workers/mkdocsnav"
 	"github.com/gardener/docforge/pkg/writers"
 	"github.com/google/go-github/v43/github"
 	"github.com/gregjones/httpcache"
@@ -27,6 +31,19 @@ import (
 	"golang.org/x/oauth2"
 )
 
+// initRegistry loads the options and creates the registry of the configured repository hosts
+func initRegistry(ctx context.Context) (repositoryhosts.Registry, options, error) {
+	var o options
+	if err := vip.Unmarshal(&o); err != nil {
+		return nil, o, err
+	}
+	rhs, err := initRepositoryHosts(ctx, o.RepositoryHostOptions, o.ParsingOptions)
+	if err != nil {
+		return nil, o, err
+	}
+	return repositoryhosts.NewRegistry(rhs...), o, nil
+}
+
 func initRepositoryHosts(ctx context.Context, o repositoryhosts.RepositoryHostOptions, options manifest.ParsingOptions) ([]repositoryhosts.RepositoryHost, error) {
 	var rhs []repositoryhosts.RepositoryHost
 	var errs *multierror.Error
@@ -99,24 +116,36 @@ func newRepositoryHost(host string, client *github.Client, httpClient *http.Clie
 }
 
 // NewReactor creates a Reactor from Options
-func getReactorConfig(options Options, hugo hugo.Hugo, rhs []repositoryhosts.RepositoryHost) Config {
+func getReactorConfig(options Options, hugo hugo.Hugo, mkDocs mkdocs.MkDocs, docusaurus docusaurus.Docusaurus, html html.HTML, rhs []repositoryhosts.RepositoryHost) Config {
 	config := Config{
 		Options:         options,
 		RepositoryHosts: rhs,
 		Hugo:            hugo,
+		MkDocs:          mkDocs,
+		Docusaurus:      docusaurus,
+		HTML:            html,
 	}
 
+	// MkDocs and Docusaurus read the documents from a folder next to their configuration
+	docsPath := config.DestinationPath
+	if config.MkDocs.Enabled || config.Docusaurus.Enabled {
+		docsPath = filepath.Join(config.DestinationPath, mkdocsnav.DocsDir)
+	}
 	if config.DryRun {
 		config.DryRunWriter = writers.NewDryRunWritersFactory(os.Stdout)
-		config.Writer = config.DryRunWriter.GetWriter(config.DestinationPath)
-		config.ResourceDownloadWriter = config.DryRunWriter.GetWriter(filepath.Join(config.DestinationPath, config.ResourcesPath))
+		config.Writer = config.DryRunWriter.GetWriter(docsPath)
+		config.ResourceDownloadWriter = config.DryRunWriter.GetWriter(filepath.Join(docsPath, config.ResourcesPath))
+		config.SiteConfigWriter = config.DryRunWriter.GetWriter(config.DestinationPath)
 	} else {
 		config.Writer = &writers.FSWriter{
-			Root: config.DestinationPath,
+			Root: docsPath,
 			Hugo: config.Hugo.Enabled,
 		}
 		config.ResourceDownloadWriter = &writers.FSWriter{
-			Root: filepath.Join(config.DestinationPath, config.ResourcesPath),
+			Root: filepath.Join(docsPath, config.ResourcesPath),
+		}
+		config.SiteConfigWriter = &writers.FSWriter{
+			Root: config.DestinationPath,
 		}
 	}
 	if len(config.GhInfoDestination) > 0 {
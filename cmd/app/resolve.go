@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// resolve output formats
+const (
+	resolveOutputJSON = "json"
+	resolveOutputYAML = "yaml"
+	resolveOutputTree = "tree"
+)
+
+// newResolveCmd creates a command printing the resolved documentation structure
+func newResolveCmd(ctx context.Context) *cobra.Command {
+	var output string
+	command := &cobra.Command{
+		Use:   "resolve",
+		Short: "Print the resolved documentation structure",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			bindFlags(cmd, append(resolveFlags, "manifest")...)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			return resolve(ctx, output, os.Stdout)
+		},
+	}
+	configureManifestPathFlag(command)
+	configureResolveFlags(command)
+	command.Flags().StringVarP(&output, "output", "o", resolveOutputYAML,
+		"Specifies the output format. Must be one of: json, yaml or tree.")
+	return command
+}
+
+func resolve(ctx context.Context, output string, w io.Writer) error {
+	registry, options, err := initRegistry(ctx)
+	if err != nil {
+		return err
+	}
+	documentNodes, err := manifest.ResolveManifestWithOptions(options.ManifestPath, registry, options.ParsingOptions)
+	if err != nil {
+		return fmt.Errorf("failed to resolve manifest %s. %+v", options.ManifestPath, err)
+	}
+	return writeResolved(w, output, documentNodes[0])
+}
+
+// writeResolved writes the resolved structure of the root node in the output format
+func writeResolved(w io.Writer, output string, root *manifest.Node) error {
+	nodes := root.Resolved()
+	switch output {
+	case resolveOutputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(nodes)
+	case resolveOutputYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(nodes); err != nil {
+			return err
+		}
+		return encoder.Close()
+	case resolveOutputTree:
+		return manifest.WriteResolvedTree(w, nodes)
+	default:
+		return fmt.Errorf("unknown output format %q. Must be one of: %s, %s or %s", output, resolveOutputJSON, resolveOutputYAML, resolveOutputTree)
+	}
+}
@@ -5,7 +5,10 @@
 package app
 
 import (
+	"github.com/gardener/docforge/cmd/docusaurus"
+	"github.com/gardener/docforge/cmd/html"
 	"github.com/gardener/docforge/cmd/hugo"
+	"github.com/gardener/docforge/cmd/mkdocs"
 	"github.com/gardener/docforge/pkg/readers/repositoryhosts"
 	"github.com/gardener/docforge/pkg/writers"
 )
@@ -25,6 +28,21 @@ type Options struct {
 	Resolve                      bool     `mapstructure:"resolve"`
 	ExtractedFilesFormats        []string `mapstructure:"extracted-files-formats"`
 	ValidateLinks                bool     `mapstructure:"validate-links"`
+	ValidateAnchors              bool     `mapstructure:"validate-anchors"`
+	SectionIndex                 bool     `mapstructure:"section-index"`
+	SectionIndexTemplate         string   `mapstructure:"section-index-template"`
+	SectionIndexAppend           bool     `mapstructure:"section-index-append"`
+	RedirectsFormat              string   `mapstructure:"redirects-format"`
+	RedirectsFile                string   `mapstructure:"redirects-file"`
+	TOC                          bool     `mapstructure:"toc"`
+	TOCMinLevel                  int      `mapstructure:"toc-min-level"`
+	TOCMaxLevel                  int      `mapstructure:"toc-max-level"`
+	SingleDocument               string   `mapstructure:"single-document"`
+	SingleDocumentImages         string   `mapstructure:"single-document-images"`
+	SearchIndexFormat            string   `mapstructure:"search-index-format"`
+	SearchIndexFile              string   `mapstructure:"search-index-file"`
+	SearchIndexFields            []string `mapstructure:"search-index-fields"`
+	SearchIndexExcludeSections   []string `mapstructure:"search-index-exclude-sections"`
 }
 
 // Writers struct that collects all the writesr
@@ -32,6 +50,7 @@ type Writers struct {
 	ResourceDownloadWriter writers.Writer
 	GitInfoWriter          writers.Writer
 	Writer                 writers.Writer
+	SiteConfigWriter       writers.Writer
 	DryRunWriter           writers.DryRunWriter
 }
 
@@ -40,5 +59,8 @@ type Config struct {
 	Options
 	Writers
 	hugo.Hugo
+	mkdocs.MkDocs
+	docusaurus.Docusaurus
+	html.HTML
 	RepositoryHosts []repositoryhosts.RepositoryHost
 }
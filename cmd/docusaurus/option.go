@@ -0,0 +1,11 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package docusaurus
+
+// Docusaurus is the configuration options for creating Docusaurus implementations
+type Docusaurus struct {
+	Enabled        bool   `mapstructure:"docusaurus"`
+	SidebarsFormat string `mapstructure:"docusaurus-sidebars-format"`
+}
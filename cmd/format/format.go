@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package format
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/spf13/cobra"
+)
+
+// NewFormatCmd creates a command rewriting manifest files
+// into their canonical form
+func NewFormatCmd() *cobra.Command {
+	var check bool
+	command := &cobra.Command{
+		Use:   "fmt [manifest files]",
+		Short: "Format manifests into their canonical form",
+		Long: "Rewrites manifest files into their canonical form: node keys are ordered, paths are normalized, " +
+			"the `file: path/name.md` shorthand is split into explicit `file` and `source` properties and " +
+			"redundant `.md` suffixes of file names are dropped. Comments are preserved.",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			c.SilenceUsage = true
+			var unformatted int
+			for _, file := range args {
+				formatted, err := formatFile(file, check)
+				if err != nil {
+					return err
+				}
+				if !formatted {
+					fmt.Println(file)
+					unformatted++
+				}
+			}
+			if unformatted > 0 {
+				return fmt.Errorf("%d manifest file(s) are not formatted", unformatted)
+			}
+			return nil
+		},
+	}
+	command.Flags().BoolVar(&check, "check", false,
+		"Lists the manifest files that are not formatted and fails instead of rewriting them.")
+	return command
+}
+
+// formatFile formats a manifest file and returns false if it isn't formatted and check is set
+func formatFile(file string, check bool) (bool, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return false, err
+	}
+	formatted, err := manifest.Format(content)
+	if err != nil {
+		return false, fmt.Errorf("can't format manifest %s : %w", file, err)
+	}
+	if bytes.Equal(content, formatted) {
+		return true, nil
+	}
+	if check {
+		return false, nil
+	}
+	info, err := os.Stat(file)
+	if err != nil {
+		return false, err
+	}
+	return true, os.WriteFile(file, formatted, info.Mode())
+}
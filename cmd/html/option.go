@@ -0,0 +1,11 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package html
+
+// HTML is the configuration options for creating HTML sites
+type HTML struct {
+	Enabled  bool   `mapstructure:"html"`
+	Template string `mapstructure:"html-template"`
+}
@@ -10,4 +10,7 @@ type Hugo struct {
 	PrettyURLs     bool     `mapstructure:"hugo-pretty-urls"`
 	BaseURL        string   `mapstructure:"hugo-base-url"`
 	IndexFileNames []string `mapstructure:"hugo-section-files"`
+	TitleFromH1    bool     `mapstructure:"hugo-title-from-h1"`
+	RemoveTitleH1  bool     `mapstructure:"hugo-remove-title-h1"`
+	ShortcodeLinks []string `mapstructure:"hugo-shortcode-links"`
 }
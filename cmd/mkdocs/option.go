@@ -0,0 +1,11 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mkdocs
+
+// MkDocs is the configuration options for creating MkDocs implementations
+type MkDocs struct {
+	Enabled bool   `mapstructure:"mkdocs"`
+	Config  string `mapstructure:"mkdocs-config"`
+}
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package frontmatter
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+const delimiter = "---"
+
+// Split returns the YAML front matter of a document, between `---` lines at its start, and the
+// content after it. A document without front matter or with an unterminated one has a nil front matter and
+// is returned as content. Lines may end with CRLF
+func Split(source []byte) (map[string]interface{}, []byte, error) {
+	first, rest, ok := cutLine(source)
+	if !ok || string(first) != delimiter {
+		return nil, source, nil
+	}
+	for pos := 0; pos < len(rest); {
+		line, next, _ := cutLine(rest[pos:])
+		if string(line) == delimiter {
+			fm := map[string]interface{}{}
+			if err := yaml.Unmarshal(rest[:pos], &fm); err != nil {
+				return nil, source, fmt.Errorf("invalid front matter: %w", err)
+			}
+			if fm == nil {
+				fm = map[string]interface{}{}
+			}
+			return fm, next, nil
+		}
+		pos = len(rest) - len(next)
+	}
+	return nil, source, nil
+}
+
+// cutLine returns the first line of b without its line ending and the content after it. The
+// returned flag reports if the line ends with a line break
+func cutLine(b []byte) ([]byte, []byte, bool) {
+	line, rest, found := bytes.Cut(b, []byte("\n"))
+	return bytes.TrimSuffix(line, []byte("\r")), rest, found
+}
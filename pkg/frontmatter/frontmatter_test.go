@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package frontmatter_test
+
+import (
+	"testing"
+
+	"github.com/gardener/docforge/pkg/frontmatter"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+func TestFrontmatter(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Front Matter Suite")
+}
+
+var _ = Describe("Front matter", func() {
+	DescribeTable("splitting documents",
+		func(source string, expectedFrontmatter map[string]interface{}, expectedContent string) {
+			fm, content, err := frontmatter.Split([]byte(source))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fm).To(Equal(expectedFrontmatter))
+			Expect(string(content)).To(Equal(expectedContent))
+		},
+		Entry("with front matter", "---\ntitle: Intro\n---\n# Intro\n", map[string]interface{}{"title": "Intro"}, "# Intro\n"),
+		Entry("with CRLF line endings", "---\r\ntitle: Intro\r\n---\r\n# Intro\r\n", map[string]interface{}{"title": "Intro"}, "# Intro\r\n"),
+		Entry("with front matter only", "---\ntitle: Intro\n---", map[string]interface{}{"title": "Intro"}, ""),
+		Entry("with empty front matter", "---\n---\n# Intro\n", map[string]interface{}{}, "# Intro\n"),
+		Entry("ending at the first delimiter", "---\ntitle: Intro\n---\n---\n", map[string]interface{}{"title": "Intro"}, "---\n"),
+		Entry("without front matter", "# Intro\n---\n", nil, "# Intro\n---\n"),
+		Entry("with unterminated front matter", "---\ntitle: Intro\n# Intro\n", nil, "---\ntitle: Intro\n# Intro\n"),
+	)
+
+	It("fails on invalid front matter", func() {
+		_, _, err := frontmatter.Split([]byte("---\ntitle: [Intro\n---\n"))
+		Expect(err).To(MatchError(ContainSubstring("invalid front matter")))
+	})
+})
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gardener/docforge/pkg/readers/link"
+	"k8s.io/klog/v2"
+)
+
+// collision strategies
+const (
+	collisionError              = "error"
+	collisionFirstWins          = "first-wins"
+	collisionLastWins           = "last-wins"
+	collisionSuffixWithRepo     = "suffix-with-repo"
+	collisionMergeAsMultiSource = "merge-as-multisource"
+)
+
+var collisionStrategies = []string{collisionError, collisionFirstWins, collisionLastWins, collisionSuffixWithRepo, collisionMergeAsMultiSource}
+
+func validateCollisionStrategy(strategy string) error {
+	if strategy == "" || slices.Contains(collisionStrategies, strategy) {
+		return nil
+	}
+	return fmt.Errorf("unknown collision strategy %q. Must be one of: %s", strategy, strings.Join(collisionStrategies, ", "))
+}
+
+// resolveCollision resolves a file colliding with a file of the same dir, according to the manifest collision strategy.
+// It returns the node to be removed from the dir, if any
+func resolveCollision(node *Node, collided *Node, manifest *Node, nodeNameToNode map[string]*Node) (*Node, error) {
+	file := node.NodePath()
+	switch manifest.CollisionStrategy {
+	case collisionFirstWins:
+		klog.Infof("collision on %s: keeping %s, dropping %s", file, collided.sourcesString(), node.sourcesString())
+		return node, nil
+	case collisionLastWins:
+		klog.Infof("collision on %s: keeping %s, dropping %s", file, node.sourcesString(), collided.sourcesString())
+		nodeNameToNode[node.File] = node
+		return collided, nil
+	case collisionSuffixWithRepo:
+		repo := sourceRepo(node)
+		name := strings.TrimSuffix(node.File, ".md") + "-" + repo + ".md"
+		if _, ok := nodeNameToNode[name]; repo == "" || repo == sourceRepo(collided) || ok {
+			return nil, fmt.Errorf("file \n\n%s\nin manifest %s that will be written in %s causes collision that can't be resolved with a repository suffix", node, manifest.ManifType.Manifest, node.Path)
+		}
+		klog.Infof("collision on %s: writing %s as %s", file, node.sourcesString(), name)
+		node.File = name
+		nodeNameToNode[name] = node
+		return nil, nil
+	case collisionMergeAsMultiSource:
+		klog.Infof("collision on %s: merging %s into %s", file, node.sourcesString(), collided.sourcesString())
+		collided.MultiSource = append(collided.sources(), node.sources()...)
+		collided.Source = ""
+		for k, v := range node.SourceOptions {
+			if collided.SourceOptions == nil {
+				collided.SourceOptions = map[string]SourceOptions{}
+			}
+			collided.SourceOptions[k] = v
+		}
+		for k, v := range node.Frontmatter {
+			if _, ok := collided.Frontmatter[k]; !ok {
+				if collided.Frontmatter == nil {
+					collided.Frontmatter = map[string]interface{}{}
+				}
+				collided.Frontmatter[k] = v
+			}
+		}
+		return node, nil
+	default:
+		return nil, fmt.Errorf("file \n\n%s\nin manifest %s that will be written in %s causes collision", node, manifest.ManifType.Manifest, node.Path)
+	}
+}
+
+// sourceRepo returns the repository of the first node source
+func sourceRepo(node *Node) string {
+	sources := node.sources()
+	if len(sources) == 0 {
+		return ""
+	}
+	r, err := link.NewResource(sources[0])
+	if err != nil {
+		return ""
+	}
+	return r.Repo
+}
+
+func (n *Node) sourcesString() string {
+	return strings.Join(n.sources(), ", ")
+}
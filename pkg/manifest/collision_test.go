@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest_test
+
+import (
+	"github.com/gardener/docforge/pkg/manifest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+// filesSources maps the output paths of the file nodes to their sources
+func filesSources(nodes []*manifest.Node) map[string][]string {
+	out := map[string][]string{}
+	for _, node := range nodes {
+		if node.Type != "file" {
+			continue
+		}
+		if node.Source != "" {
+			out[node.NodePath()] = []string{node.Source}
+		} else {
+			out[node.NodePath()] = node.MultiSource
+		}
+	}
+	return out
+}
+
+var _ = Describe("Collision strategies", func() {
+	const (
+		one = "https://github.com/org/one/blob/master/a.md"
+		two = "https://github.com/org/two/blob/master/a.md"
+	)
+
+	DescribeTable("resolving collisions",
+		func(strategy string, expected map[string][]string, title string) {
+			nodes, err := manifest.ResolveManifestWithOptions("tests/examples/collision.yaml", fakeRegistry(), manifest.ParsingOptions{CollisionStrategy: strategy})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filesSources(nodes)).To(Equal(expected))
+			for _, node := range nodes {
+				if node.NodePath() == "docs/a.md" {
+					Expect(node.Frontmatter["title"]).To(Equal(title))
+				}
+			}
+		},
+		Entry("first-wins", "first-wins", map[string][]string{"docs/a.md": {one}}, "One"),
+		Entry("last-wins", "last-wins", map[string][]string{"docs/a.md": {two}}, "Two"),
+		Entry("suffix-with-repo", "suffix-with-repo", map[string][]string{"docs/a.md": {one}, "docs/a-two.md": {two}}, "One"),
+		Entry("merge-as-multisource", "merge-as-multisource", map[string][]string{"docs/a.md": {one, two}}, "One"),
+	)
+
+	It("fails on collision by default", func() {
+		_, err := manifest.ResolveManifest("tests/examples/collision.yaml", fakeRegistry())
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("causes collision"))
+	})
+
+	It("fails for an unknown strategy", func() {
+		_, err := manifest.ResolveManifestWithOptions("tests/examples/collision.yaml", fakeRegistry(), manifest.ParsingOptions{CollisionStrategy: "random"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unknown collision strategy"))
+	})
+
+	It("uses the strategy of the root manifest", func() {
+		nodes, err := manifest.ResolveManifest("tests/examples/collision_strategy.yaml", fakeRegistry())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filesSources(nodes)).To(Equal(map[string][]string{"docs/a.md": {two}}))
+	})
+
+	It("overrides the root manifest strategy with the options", func() {
+		nodes, err := manifest.ResolveManifestWithOptions("tests/examples/collision_strategy.yaml", fakeRegistry(), manifest.ParsingOptions{CollisionStrategy: "first-wins"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filesSources(nodes)).To(Equal(map[string][]string{"docs/a.md": {one}}))
+	})
+})
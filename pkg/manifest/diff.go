@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Diff is the difference between the output files of two resolved manifests
+type Diff struct {
+	// Added are the files only in the new manifest
+	Added []DiffFile `json:"added,omitempty"`
+	// Removed are the files only in the old manifest
+	Removed []DiffFile `json:"removed,omitempty"`
+	// Moved are the files written to a different path
+	Moved []DiffMove `json:"moved,omitempty"`
+	// Retitled are the files which title changed
+	Retitled []DiffRetitle `json:"retitled,omitempty"`
+	// SourceChanged are the files which sources changed
+	SourceChanged []DiffSources `json:"sourceChanged,omitempty"`
+}
+
+// DiffFile is an added or removed file
+type DiffFile struct {
+	Path    string   `json:"path"`
+	Sources []string `json:"sources,omitempty"`
+}
+
+// DiffMove is a file written to a different path
+type DiffMove struct {
+	Source  string `json:"source"`
+	OldPath string `json:"oldPath"`
+	NewPath string `json:"newPath"`
+}
+
+// DiffRetitle is a file which title changed
+type DiffRetitle struct {
+	Path     string `json:"path"`
+	OldTitle string `json:"oldTitle,omitempty"`
+	NewTitle string `json:"newTitle,omitempty"`
+}
+
+// DiffSources is a file which sources changed
+type DiffSources struct {
+	Path       string   `json:"path"`
+	OldSources []string `json:"oldSources,omitempty"`
+	NewSources []string `json:"newSources,omitempty"`
+}
+
+// IsEmpty returns true if there are no differences
+func (d *Diff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Moved) == 0 && len(d.Retitled) == 0 && len(d.SourceChanged) == 0
+}
+
+// DiffManifests compares the file nodes of two resolved manifests. Files are matched by their first
+// source so moves are detected, unmatched files with the same path are reported as source changes
+func DiffManifests(oldNodes []*Node, newNodes []*Node) *Diff {
+	d := &Diff{}
+	oldFiles, newFiles := filesBySource(oldNodes), filesBySource(newNodes)
+	var added, removed []*Node
+	for _, key := range sortedKeys(oldFiles) {
+		olds, news := oldFiles[key], slices.Clone(newFiles[key])
+		// files keeping their path are paired first
+		var unmatched []*Node
+		for _, o := range olds {
+			i := slices.IndexFunc(news, func(n *Node) bool { return n.NodePath() == o.NodePath() })
+			if i < 0 {
+				unmatched = append(unmatched, o)
+				continue
+			}
+			d.compare(o, news[i])
+			news = slices.Delete(news, i, i+1)
+		}
+		for len(unmatched) > 0 && len(news) > 0 {
+			d.Moved = append(d.Moved, DiffMove{Source: key, OldPath: unmatched[0].NodePath(), NewPath: news[0].NodePath()})
+			d.compare(unmatched[0], news[0])
+			unmatched, news = unmatched[1:], news[1:]
+		}
+		removed = append(removed, unmatched...)
+		newFiles[key] = news
+	}
+	for _, key := range sortedKeys(newFiles) {
+		added = append(added, newFiles[key]...)
+	}
+	for _, r := range removed {
+		i := slices.IndexFunc(added, func(n *Node) bool { return n.NodePath() == r.NodePath() })
+		if i < 0 {
+			d.Removed = append(d.Removed, DiffFile{Path: r.NodePath(), Sources: r.sources()})
+			continue
+		}
+		d.SourceChanged = append(d.SourceChanged, DiffSources{Path: r.NodePath(), OldSources: r.sources(), NewSources: added[i].sources()})
+		d.compareTitle(r, added[i])
+		added = slices.Delete(added, i, i+1)
+	}
+	for _, a := range added {
+		d.Added = append(d.Added, DiffFile{Path: a.NodePath(), Sources: a.sources()})
+	}
+	sortDiff(d)
+	return d
+}
+
+// compare records the title and source changes of matched files
+func (d *Diff) compare(oldNode *Node, newNode *Node) {
+	if !slices.Equal(oldNode.sources(), newNode.sources()) {
+		d.SourceChanged = append(d.SourceChanged, DiffSources{Path: newNode.NodePath(), OldSources: oldNode.sources(), NewSources: newNode.sources()})
+	}
+	d.compareTitle(oldNode, newNode)
+}
+
+func (d *Diff) compareTitle(oldNode *Node, newNode *Node) {
+	if oldTitle, newTitle := oldNode.title(), newNode.title(); oldTitle != newTitle {
+		d.Retitled = append(d.Retitled, DiffRetitle{Path: newNode.NodePath(), OldTitle: oldTitle, NewTitle: newTitle})
+	}
+}
+
+// WriteText writes the diff in a human-readable form
+func (d *Diff) WriteText(w io.Writer) error {
+	var lines []string
+	for _, f := range d.Added {
+		lines = append(lines, fmt.Sprintf("added    %s <- %s", f.Path, strings.Join(f.Sources, ", ")))
+	}
+	for _, f := range d.Removed {
+		lines = append(lines, fmt.Sprintf("removed  %s <- %s", f.Path, strings.Join(f.Sources, ", ")))
+	}
+	for _, m := range d.Moved {
+		lines = append(lines, fmt.Sprintf("moved    %s -> %s (%s)", m.OldPath, m.NewPath, m.Source))
+	}
+	for _, r := range d.Retitled {
+		lines = append(lines, fmt.Sprintf("retitled %s : %q -> %q", r.Path, r.OldTitle, r.NewTitle))
+	}
+	for _, s := range d.SourceChanged {
+		lines = append(lines, fmt.Sprintf("source   %s : %s -> %s", s.Path, strings.Join(s.OldSources, ", "), strings.Join(s.NewSources, ", ")))
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sources returns the source URLs of a file node
+func (n *Node) sources() []string {
+	if n.Source != "" {
+		return []string{n.Source}
+	}
+	return n.MultiSource
+}
+
+// title returns the front matter title of a node
+func (n *Node) title() string {
+	if title, ok := n.Frontmatter["title"]; ok {
+		return fmt.Sprint(title)
+	}
+	return ""
+}
+
+// filesBySource groups the file nodes by their first source. Files without source are keyed by their path
+func filesBySource(nodes []*Node) map[string][]*Node {
+	out := map[string][]*Node{}
+	for _, n := range nodes {
+		if n.Type != "file" {
+			continue
+		}
+		key := n.NodePath()
+		if sources := n.sources(); len(sources) > 0 {
+			key = sources[0]
+		}
+		out[key] = append(out[key], n)
+	}
+	return out
+}
+
+func sortedKeys(m map[string][]*Node) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func sortDiff(d *Diff) {
+	slices.SortStableFunc(d.Added, func(a, b DiffFile) int { return strings.Compare(a.Path, b.Path) })
+	slices.SortStableFunc(d.Removed, func(a, b DiffFile) int { return strings.Compare(a.Path, b.Path) })
+	slices.SortStableFunc(d.Moved, func(a, b DiffMove) int { return strings.Compare(a.OldPath, b.OldPath) })
+	slices.SortStableFunc(d.Retitled, func(a, b DiffRetitle) int { return strings.Compare(a.Path, b.Path) })
+	slices.SortStableFunc(d.SourceChanged, func(a, b DiffSources) int { return strings.Compare(a.Path, b.Path) })
+}
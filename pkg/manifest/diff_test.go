@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest_test
+
+import (
+	"strings"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Diff", func() {
+	var diff *manifest.Diff
+
+	BeforeEach(func() {
+		oldNodes, err := manifest.ResolveManifest("tests/examples/diff_old.yaml", fakeRegistry())
+		Expect(err).ToNot(HaveOccurred())
+		newNodes, err := manifest.ResolveManifest("tests/examples/diff_new.yaml", fakeRegistry())
+		Expect(err).ToNot(HaveOccurred())
+		diff = manifest.DiffManifests(oldNodes, newNodes)
+	})
+
+	It("reports the changes keyed by source", func() {
+		Expect(diff.Added).To(Equal([]manifest.DiffFile{{Path: "docs/e.md", Sources: []string{"https://test/docs/e.md"}}}))
+		Expect(diff.Removed).To(Equal([]manifest.DiffFile{{Path: "docs/f.md", Sources: []string{"https://test/docs/f.md"}}}))
+		Expect(diff.Moved).To(Equal([]manifest.DiffMove{{Source: "https://test/docs/b.md", OldPath: "docs/b.md", NewPath: "guide/b.md"}}))
+		Expect(diff.Retitled).To(Equal([]manifest.DiffRetitle{{Path: "docs/a.md", OldTitle: "A", NewTitle: "Alpha"}}))
+		Expect(diff.SourceChanged).To(Equal([]manifest.DiffSources{
+			{Path: "docs/c.md", OldSources: []string{"https://test/docs/c.md"}, NewSources: []string{"https://test/docs/c2.md"}},
+			{Path: "docs/d.md", OldSources: []string{"https://test/docs/d.md"}, NewSources: []string{"https://test/docs/d.md", "https://test/docs/d2.md"}},
+		}))
+	})
+
+	It("writes the changes as text", func() {
+		var b strings.Builder
+		Expect(diff.WriteText(&b)).To(Succeed())
+		Expect(b.String()).To(ContainSubstring("moved    docs/b.md -> guide/b.md (https://test/docs/b.md)\n"))
+		Expect(b.String()).To(ContainSubstring("retitled docs/a.md : \"A\" -> \"Alpha\"\n"))
+	})
+
+	It("is empty for the same manifest", func() {
+		nodes, err := manifest.ResolveManifest("tests/examples/diff_old.yaml", fakeRegistry())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(manifest.DiffManifests(nodes, nodes).IsEmpty()).To(BeTrue())
+	})
+})
@@ -7,3 +7,8 @@ package manifest
 func (n *Node) RemoveParent() {
 	n.parent = nil
 }
+
+func (n *Node) RemoveProvenance() {
+	n.manifestURL = ""
+	n.fileTreeURL = ""
+}
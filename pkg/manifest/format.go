@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// nodeKeys is the canonical key order of manifest nodes
+var nodeKeys = []string{"manifest", "dir", "file", "fileTree", "source", "multiSource", "sourceOptions", "content", "template", "fileName", "excludeFiles", "folderFlattening", "collisionStrategy", "patches", "properties", "frontmatter", "frontmatterSchema", "transformers", "structure"}
+
+// patchKeys is the canonical key order of patches
+var patchKeys = []string{"path", "source", "op", "node", "frontmatter"}
+
+// Format rewrites manifest content into its canonical form. Comments are preserved
+func Format(content []byte) ([]byte, error) {
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(content, doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return content, nil
+	}
+	if err := formatNode(doc.Content[0]); err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func formatNode(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("manifest node at line %d is not a mapping", node.Line)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		value := node.Content[i+1]
+		switch node.Content[i].Value {
+		case "manifest", "dir", "fileTree", "source":
+			normalizePathValue(value)
+		case "multiSource", "excludeFiles":
+			for _, item := range value.Content {
+				normalizePathValue(item)
+			}
+		case "sourceOptions":
+			for j := 0; j < len(value.Content); j += 2 {
+				normalizePathValue(value.Content[j])
+			}
+		case "structure":
+			for _, child := range value.Content {
+				if err := formatNode(child); err != nil {
+					return err
+				}
+			}
+		case "patches":
+			for _, patch := range value.Content {
+				if err := formatPatch(patch); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	formatFile(node)
+	sortKeys(node, nodeKeys)
+	return nil
+}
+
+func formatPatch(patch *yaml.Node) error {
+	if patch.Kind != yaml.MappingNode {
+		return fmt.Errorf("patch at line %d is not a mapping", patch.Line)
+	}
+	for i := 0; i+1 < len(patch.Content); i += 2 {
+		value := patch.Content[i+1]
+		switch patch.Content[i].Value {
+		case "path", "source":
+			normalizePathValue(value)
+		case "node":
+			if err := formatNode(value); err != nil {
+				return err
+			}
+		}
+	}
+	sortKeys(patch, patchKeys)
+	return nil
+}
+
+// formatFile converts the file shorthand into the explicit file and source form
+// and drops the redundant .md suffix of the file name
+func formatFile(node *yaml.Node) {
+	file := mappingValue(node, "file")
+	if file == nil || file.Kind != yaml.ScalarNode || isNameExpression(file.Value) {
+		return
+	}
+	if strings.Contains(file.Value, "/") {
+		// the file path overrides the source, see resolveRelativeLinks
+		source := mappingValue(node, "source")
+		if source == nil {
+			source = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str"}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "source"}, source)
+		}
+		source.Value = normalizePath(file.Value)
+		file.Value = path.Base(source.Value)
+	}
+	if mappingValue(node, "source") == nil && mappingValue(node, "multiSource") == nil &&
+		mappingValue(node, "content") == nil && mappingValue(node, "template") == nil {
+		return
+	}
+	if name := strings.TrimSuffix(file.Value, ".md"); name != "" {
+		file.Value = name
+	}
+}
+
+// mappingValue returns the value of a mapping key or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// sortKeys orders the mapping keys as listed in order. Unknown keys keep their relative order after the known ones
+func sortKeys(node *yaml.Node, order []string) {
+	rank := func(key string) int {
+		for i, k := range order {
+			if k == key {
+				return i
+			}
+		}
+		return len(order)
+	}
+	pairs := make([][2]*yaml.Node, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		pairs = append(pairs, [2]*yaml.Node{node.Content[i], node.Content[i+1]})
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return rank(pairs[i][0].Value) < rank(pairs[j][0].Value)
+	})
+	node.Content = node.Content[:0]
+	for _, pair := range pairs {
+		node.Content = append(node.Content, pair[0], pair[1])
+	}
+}
+
+func normalizePathValue(node *yaml.Node) {
+	if node.Kind == yaml.ScalarNode {
+		node.Value = normalizePath(node.Value)
+	}
+}
+
+// normalizePath cleans relative and absolute paths, URLs are left untouched
+func normalizePath(p string) string {
+	if p == "" || strings.Contains(p, "://") {
+		return p
+	}
+	return path.Clean(p)
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest_test
+
+import (
+	"github.com/gardener/docforge/pkg/manifest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Format", func() {
+	DescribeTable("formatting manifests",
+		func(content string, expected string) {
+			formatted, err := manifest.Format([]byte(content))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(formatted)).To(Equal(expected))
+			again, err := manifest.Format(formatted)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(again)).To(Equal(expected))
+		},
+		Entry("orders keys",
+			"structure:\n- structure:\n  - source: a.md\n    frontmatter:\n      title: A\n    file: a\n  dir: docs\n",
+			"structure:\n  - dir: docs\n    structure:\n      - file: a\n        source: a.md\n        frontmatter:\n          title: A\n",
+		),
+		Entry("normalizes paths",
+			"structure:\n- fileTree: ./website/\n- manifest: ./one/../other.yaml\n- multiSource:\n  - ./a.md\n  - https://github.com/org/repo/blob/master/b.md\n  file: ab\n",
+			"structure:\n  - fileTree: website\n  - manifest: other.yaml\n  - file: ab\n    multiSource:\n      - a.md\n      - https://github.com/org/repo/blob/master/b.md\n",
+		),
+		Entry("splits the file shorthand and drops redundant .md suffixes",
+			"structure:\n- file: ./docs/a.md\n- file: b.md\n  source: b.md\n- file: _index.md\n- file: $name.md\n  source: c.md\n",
+			"structure:\n  - file: a\n    source: docs/a.md\n  - file: b\n    source: b.md\n  - file: _index.md\n  - file: $name.md\n    source: c.md\n",
+		),
+		Entry("preserves comments",
+			"# docs\nstructure:\n# website\n- fileTree: /website # root\n",
+			"# docs\nstructure:\n  # website\n  - fileTree: /website # root\n",
+		),
+		Entry("formats patches",
+			"structure:\n- manifest: base.yaml\n  patches:\n  - op: insertAfter\n    node:\n      source: ./x.md\n      file: x.md\n    path: ./docs/a\n",
+			"structure:\n  - manifest: base.yaml\n    patches:\n      - path: docs/a\n        op: insertAfter\n        node:\n          file: x\n          source: x.md\n",
+		),
+	)
+	It("fails for a node that is not a mapping", func() {
+		_, err := manifest.Format([]byte("structure:\n- docs\n"))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"reflect"
+	"slices"
 	"strings"
 
 	resourcehandlers "github.com/gardener/docforge/pkg/readers/repositoryhosts"
@@ -58,9 +60,23 @@ func loadManifestStructure(node *Node, parent *Node, manifest *Node, r resourceh
 	if err != nil {
 		return fmt.Errorf("can't get manifest file content : %w", err)
 	}
+	// patches belong to the including manifest
+	patches := node.Patches
 	if err = yaml.Unmarshal([]byte(content), node); err != nil {
 		return fmt.Errorf("can't parse manifest %s yaml content : %w", node.Manifest, err)
 	}
+	if patches != nil {
+		node.Patches = patches
+	}
+	// the content of included manifests is flattened with the rules of the root manifest
+	if node != manifest && node.FolderFlattening != nil {
+		return fmt.Errorf("folderFlattening is only supported in the root manifest, found in %s", node.Manifest)
+	}
+	return nil
+}
+
+func setManifestURL(node *Node, _ *Node, manifest *Node, _ resourcehandlers.Registry) error {
+	node.manifestURL = manifest.Manifest
 	return nil
 }
 
@@ -69,6 +85,9 @@ func moveManifestContentIntoTree(node *Node, parent *Node, manifest *Node, r res
 		return nil
 	}
 	if parent != nil {
+		if len(node.Patches) > 0 {
+			node.overlaid = node.Structure
+		}
 		parent.Structure = append(parent.Structure, node.Structure...)
 		node.Structure = nil
 	}
@@ -95,12 +114,38 @@ func decideNodeType(node *Node, _ *Node, _ *Node, _ resourcehandlers.Registry) e
 		return fmt.Errorf("there is a node \n\n%s\nof no type", node)
 	case 1:
 		node.Type = candidateType[0]
-		return nil
+		return checkFileContent(node)
 	default:
 		return fmt.Errorf("there is a node \n\n%s\ntrying to be %s", node, strings.Join(candidateType, ","))
 	}
 }
 
+// checkFileContent checks that a file node has a single kind of content
+func checkFileContent(node *Node) error {
+	if node.Type != "file" {
+		return nil
+	}
+	contents := 0
+	for _, set := range []bool{node.Source != "", len(node.MultiSource) > 0, node.Content != "", node.Template != ""} {
+		if set {
+			contents++
+		}
+	}
+	if contents > 1 {
+		return fmt.Errorf("file node \n\n%s\ncan have only one of source, multiSource, content or template", node)
+	}
+	for source, options := range node.SourceOptions {
+		fileSource := strings.Contains(node.File, "/") && source == node.File
+		if source != node.Source && !fileSource && !slices.Contains(node.MultiSource, source) {
+			return fmt.Errorf("file node \n\n%s\nhas source options for %s which is not one of its sources", node, source)
+		}
+		if options.DemoteHeadings < 0 || options.DemoteHeadings > 5 {
+			return fmt.Errorf("file node \n\n%s\nhas invalid demoteHeadings %d for %s, must be between 0 and 5", node, options.DemoteHeadings, source)
+		}
+	}
+	return nil
+}
+
 func calculatePath(node *Node, parent *Node, _ *Node, _ resourcehandlers.Registry) error {
 	if parent == nil {
 		return nil
@@ -126,8 +171,8 @@ func resolveRelativeLinks(node *Node, _ *Node, manifest *Node, r resourcehandler
 	)
 	switch node.Type {
 	case "file":
-		// Don't calculate source for empty _index.md file
-		if node.File == "_index.md" && node.Source == "" {
+		// Don't calculate source for empty _index.md file and inline content
+		if (node.File == "_index.md" || node.IsInline()) && node.Source == "" {
 			return nil
 		}
 		if strings.Contains(node.File, "/") {
@@ -141,6 +186,11 @@ func resolveRelativeLinks(node *Node, _ *Node, manifest *Node, r resourcehandler
 		if newLink, err = fs.ToAbsLink(manifest.Manifest, node.Source); err != nil {
 			return fmt.Errorf("cant build node's absolute link %s : %w", node.Source, err)
 		}
+		// the source options follow the resolved source
+		if options, ok := node.SourceOptions[node.Source]; ok && newLink != node.Source {
+			delete(node.SourceOptions, node.Source)
+			node.SourceOptions[newLink] = options
+		}
 		node.Source = newLink
 	case "fileTree":
 		fs, err := r.Get(manifest.Manifest)
@@ -158,6 +208,13 @@ func resolveRelativeLinks(node *Node, _ *Node, manifest *Node, r resourcehandler
 func extractFilesFromNode(node *Node, parent *Node, manifest *Node, r resourcehandlers.Registry) error {
 	switch node.Type {
 	case "file":
+		if isNameExpression(node.File) {
+			name, err := evaluateName(node.File, node.Source, node.Frontmatter, r)
+			if err != nil {
+				return err
+			}
+			node.File = name
+		}
 		if !strings.HasSuffix(node.File, ".md") {
 			node.File += ".md"
 		}
@@ -170,7 +227,7 @@ func extractFilesFromNode(node *Node, parent *Node, manifest *Node, r resourceha
 		if err != nil {
 			return err
 		}
-		if err := constructNodeTree(files, node, parent); err != nil {
+		if err := constructNodeTree(files, node, parent, r); err != nil {
 			return err
 		}
 		removeNodeFromParent(node, parent)
@@ -189,9 +246,12 @@ func removeNodeFromParent(node *Node, parent *Node) {
 	}
 }
 
-func constructNodeTree(files []string, node *Node, parent *Node) error {
+func constructNodeTree(files []string, node *Node, parent *Node, r resourcehandlers.Registry) error {
 	pathToDirNode := map[string]*Node{}
 	pathToDirNode[node.Path] = parent
+	getParentNode := func(parentPath string) *Node {
+		return getParrentNode(pathToDirNode, parentPath, node)
+	}
 	for _, file := range files {
 		extension := path.Ext(file)
 		if extension != ".md" && extension != "" {
@@ -212,24 +272,31 @@ func constructNodeTree(files []string, node *Node, parent *Node) error {
 			return err
 		}
 		fileName := path.Base(file)
+		if node.FileName != "" {
+			if fileName, err = evaluateName(node.FileName, source, nil, r); err != nil {
+				return err
+			}
+		}
 		if !strings.HasSuffix(fileName, ".md") {
 			fileName = fileName + ".md"
 		}
 		filePath := path.Join(node.Path, path.Dir(file))
-		parentNode := getParrentNode(pathToDirNode, filePath)
+		parentNode := getParentNode(filePath)
 		parentNode.Structure = append(parentNode.Structure, &Node{
 			FileType: FileType{
 				File:   fileName,
 				Source: source,
 			},
-			Type: "file",
-			Path: filePath,
+			Type:        "file",
+			Path:        filePath,
+			manifestURL: node.manifestURL,
+			fileTreeURL: node.FileTree,
 		})
 	}
 	return nil
 }
 
-func getParrentNode(pathToDirNode map[string]*Node, parentPath string) *Node {
+func getParrentNode(pathToDirNode map[string]*Node, parentPath string, fileTree *Node) *Node {
 	if parent, ok := pathToDirNode[parentPath]; ok {
 		return parent
 	}
@@ -238,10 +305,12 @@ func getParrentNode(pathToDirNode map[string]*Node, parentPath string) *Node {
 		DirType: DirType{
 			Dir: path.Base(parentPath),
 		},
-		Type: "dir",
-		Path: parentPath,
+		Type:        "dir",
+		Path:        parentPath,
+		manifestURL: fileTree.manifestURL,
+		fileTreeURL: fileTree.FileTree,
 	}
-	outParent := getParrentNode(pathToDirNode, path.Dir(parentPath))
+	outParent := getParrentNode(pathToDirNode, path.Dir(parentPath), fileTree)
 	outParent.Structure = append(outParent.Structure, out)
 	pathToDirNode[parentPath] = out
 	return out
@@ -249,6 +318,7 @@ func getParrentNode(pathToDirNode map[string]*Node, parentPath string) *Node {
 
 func mergeFolders(node *Node, parent *Node, manifest *Node, _ resourcehandlers.Registry) error {
 	nodeNameToNode := map[string]*Node{}
+	removed := map[*Node]bool{}
 	for _, child := range node.Structure {
 		switch child.Type {
 		case "dir":
@@ -259,38 +329,96 @@ func mergeFolders(node *Node, parent *Node, manifest *Node, _ resourcehandlers.R
 				nodeNameToNode[child.Dir] = child
 			}
 		case "file":
-			if _, ok := nodeNameToNode[child.File]; ok {
-				if child.Frontmatter != nil && nodeNameToNode[child.File].Frontmatter != nil && child.Frontmatter["persona"] != nodeNameToNode[child.File].Frontmatter["persona"] {
-					persona, _ := child.Frontmatter["persona"].(string)
-					child.File = strings.ReplaceAll(child.File, ".md", "-"+personaToDir[persona]+".md")
-				} else {
-					return fmt.Errorf("file \n\n%s\nin manifest %s that will be written in %s causes collision", child, manifest.ManifType.Manifest, child.Path)
-				}
+			collided, ok := nodeNameToNode[child.File]
+			if !ok {
+				nodeNameToNode[child.File] = child
+				continue
+			}
+			if suffix := collisionSuffix(child, collided, manifest.flattenRules()); suffix != "" {
+				child.File = strings.TrimSuffix(child.File, ".md") + "-" + suffix + ".md"
+				nodeNameToNode[child.File] = child
+				continue
+			}
+			drop, err := resolveCollision(child, collided, manifest, nodeNameToNode)
+			if err != nil {
+				return err
+			}
+			if drop != nil {
+				removed[drop] = true
 			}
-			nodeNameToNode[child.File] = child
 		}
 	}
+	if len(removed) > 0 {
+		node.Structure = slices.DeleteFunc(node.Structure, func(n *Node) bool { return removed[n] })
+	}
 	return nil
 }
 
-var dirToPersona = map[string]string{"usage": "Users", "operations": "Operators", "development": "Developers"}
-var personaToDir = map[string]string{"Users": "usage", "Operators": "operations", "Developers": "development"}
+// defaultFlattenRules flatten the Gardener persona folders
+var defaultFlattenRules = []FlattenRule{
+	{Dir: "usage", Frontmatter: map[string]interface{}{"persona": "Users"}, Alias: "/$path/$dir/$name/", Suffix: "usage"},
+	{Dir: "operations", Frontmatter: map[string]interface{}{"persona": "Operators"}, Alias: "/$path/$dir/$name/", Suffix: "operations"},
+	{Dir: "development", Frontmatter: map[string]interface{}{"persona": "Developers"}, Alias: "/$path/$dir/$name/", Suffix: "development"},
+}
+
+// flattenRules returns the folder flattening rules configured in a manifest node
+func (n *Node) flattenRules() []FlattenRule {
+	if n.FolderFlattening == nil {
+		return defaultFlattenRules
+	}
+	if n.FolderFlattening.Disabled {
+		return nil
+	}
+	return n.FolderFlattening.Rules
+}
+
+// collisionSuffix returns the suffix of the rule that flattened node, but not the node it collides with
+func collisionSuffix(node *Node, collided *Node, rules []FlattenRule) string {
+	for _, rule := range rules {
+		if rule.Suffix != "" && len(rule.Frontmatter) > 0 && rule.matches(node) && !rule.matches(collided) {
+			return rule.Suffix
+		}
+	}
+	return ""
+}
 
-func resolvePersonaFolders(node *Node, parent *Node, manifest *Node, _ resourcehandlers.Registry) error {
-	if node.Type == "dir" && (node.Dir == "development" || node.Dir == "operations" || node.Dir == "usage") {
+// matches returns true if the node front matter contains the rule front matter
+func (f FlattenRule) matches(node *Node) bool {
+	for k, v := range f.Frontmatter {
+		if !reflect.DeepEqual(node.Frontmatter[k], v) {
+			return false
+		}
+	}
+	return true
+}
+
+func flattenFolders(node *Node, parent *Node, manifest *Node, _ resourcehandlers.Registry) error {
+	if node.Type != "dir" {
+		return nil
+	}
+	for _, rule := range manifest.flattenRules() {
+		if node.Dir != rule.Dir {
+			continue
+		}
 		for _, child := range node.Structure {
 			if child.Frontmatter == nil {
 				child.Frontmatter = map[string]interface{}{}
 			}
-			child.Frontmatter["persona"] = dirToPersona[node.Dir]
-			finalAlias := strings.TrimSuffix(child.Name(), ".md") + "/"
-			if child.Name() == "_index.md" {
-				finalAlias = ""
+			for k, v := range rule.Frontmatter {
+				child.Frontmatter[k] = v
+			}
+			if rule.Alias != "" {
+				name := strings.TrimSuffix(child.Name(), ".md")
+				if child.Name() == "_index.md" {
+					name = ""
+				}
+				alias := strings.NewReplacer("$path", parent.Path, "$dir", node.Dir, "$name", name).Replace(rule.Alias)
+				child.Frontmatter["aliases"] = []interface{}{path.Clean(alias) + "/"}
 			}
-			child.Frontmatter["aliases"] = []interface{}{"/" + parent.Path + "/" + node.Dir + "/" + finalAlias}
 		}
 		parent.Structure = append(parent.Structure, node.Structure...)
 		removeNodeFromParent(node, parent)
+		return nil
 	}
 	return nil
 }
@@ -309,6 +437,41 @@ func propagateFrontmatter(node *Node, parent *Node, manifest *Node, _ resourceha
 	return nil
 }
 
+func propagateTransformers(node *Node, parent *Node, _ *Node, _ resourcehandlers.Registry) error {
+	if parent != nil && len(parent.Transformers) > 0 {
+		transformers := map[string]interface{}{}
+		for k, v := range parent.Transformers {
+			transformers[k] = v
+		}
+		for k, v := range node.Transformers {
+			transformers[k] = v
+		}
+		node.Transformers = transformers
+	}
+	return nil
+}
+
+func propagateFrontmatterSchema(node *Node, parent *Node, _ *Node, _ resourcehandlers.Registry) error {
+	for key, declared := range node.FrontmatterSchema {
+		switch declared.Type {
+		case "", "string", "number", "boolean", "list", "map":
+		default:
+			return fmt.Errorf("node %s declares front matter key %s with unknown type %s", node.NodePath(), key, declared.Type)
+		}
+	}
+	if parent != nil && len(parent.FrontmatterSchema) > 0 {
+		schema := map[string]FrontmatterKey{}
+		for k, v := range parent.FrontmatterSchema {
+			schema[k] = v
+		}
+		for k, v := range node.FrontmatterSchema {
+			schema[k] = v
+		}
+		node.FrontmatterSchema = schema
+	}
+	return nil
+}
+
 func setParent(node *Node, parent *Node, _ *Node, _ resourcehandlers.Registry) error {
 	node.parent = parent
 	return nil
@@ -347,6 +510,11 @@ func calculateAliases(node *Node, parent *Node, _ *Node, _ resourcehandlers.Regi
 
 // ResolveManifest collects files in FileCollector from a given url and resourcehandlers.FileSource
 func ResolveManifest(url string, r resourcehandlers.Registry) ([]*Node, error) {
+	return ResolveManifestWithOptions(url, r, ParsingOptions{})
+}
+
+// ResolveManifestWithOptions resolves the manifest into a node tree. Options override the root manifest settings
+func ResolveManifestWithOptions(url string, r resourcehandlers.Registry, options ParsingOptions) ([]*Node, error) {
 	manifest := Node{
 		ManifType: ManifType{
 			Manifest: url,
@@ -355,9 +523,21 @@ func ResolveManifest(url string, r resourcehandlers.Registry) ([]*Node, error) {
 	if err := processManifest(loadManifestStructure, &manifest, nil, &manifest, r); err != nil {
 		return nil, err
 	}
+	if options.CollisionStrategy != "" {
+		manifest.CollisionStrategy = options.CollisionStrategy
+	}
+	if options.FolderFlattening != nil {
+		manifest.FolderFlattening = options.FolderFlattening
+	}
+	if err := validateCollisionStrategy(manifest.CollisionStrategy); err != nil {
+		return nil, err
+	}
 	if err := processManifest(decideNodeType, &manifest, nil, &manifest, r); err != nil {
 		return nil, err
 	}
+	if err := processManifest(setManifestURL, &manifest, nil, &manifest, r); err != nil {
+		return nil, err
+	}
 	if err := processManifest(calculatePath, &manifest, nil, &manifest, r); err != nil {
 		return nil, err
 	}
@@ -370,10 +550,13 @@ func ResolveManifest(url string, r resourcehandlers.Registry) ([]*Node, error) {
 	if err := processManifest(moveManifestContentIntoTree, &manifest, nil, &manifest, r); err != nil {
 		return nil, err
 	}
+	if err := processManifest(applyOverlays, &manifest, nil, &manifest, r); err != nil {
+		return nil, err
+	}
 	if err := processManifest(mergeFolders, &manifest, nil, &manifest, r); err != nil {
 		return nil, err
 	}
-	if err := processManifest(resolvePersonaFolders, &manifest, nil, &manifest, r); err != nil {
+	if err := processManifest(flattenFolders, &manifest, nil, &manifest, r); err != nil {
 		return nil, err
 	}
 	if err := processManifest(calculateAliases, &manifest, nil, &manifest, r); err != nil {
@@ -394,6 +577,12 @@ func ResolveManifest(url string, r resourcehandlers.Registry) ([]*Node, error) {
 	if err := processManifest(propagateFrontmatter, &manifest, nil, &manifest, r); err != nil {
 		return nil, err
 	}
+	if err := processManifest(propagateTransformers, &manifest, nil, &manifest, r); err != nil {
+		return nil, err
+	}
+	if err := processManifest(propagateFrontmatterSchema, &manifest, nil, &manifest, r); err != nil {
+		return nil, err
+	}
 	return getAllNodes(&manifest), nil
 }
 
@@ -5,6 +5,7 @@ package manifest_test
 // SPDX-License-Identifier: Apache-2.0
 
 import (
+	"context"
 	"embed"
 	"errors"
 	"fmt"
@@ -26,6 +27,41 @@ func TestManifest(t *testing.T) {
 	RunSpecs(t, "Manifest Suite")
 }
 
+// fakeRegistry returns a registry serving the test examples
+func fakeRegistry() *repositoryhostsfakes.FakeRegistry {
+	fakeFiles := &repositoryhostsfakes.FakeRepositoryHost{}
+	fakeFiles.ManifestFromURLCalls(func(url string) (string, error) {
+		url = strings.TrimPrefix(url, "https://test")
+		content, err := examples.ReadFile(url)
+		return string(content), err
+	})
+	fakeFiles.ToAbsLinkCalls(func(url, link string) (string, error) {
+		if strings.HasPrefix(link, "/") {
+			return "https://test" + link, nil
+		}
+		return link, nil
+	})
+	fakeFiles.FileTreeFromURLCalls(func(url string) ([]string, error) {
+		files := map[string][]string{}
+		files["https://test/website"] = []string{"blog/2023/_index.md"}
+		files["https://test/blogs"] = []string{"2023/one", "2023/two.md"}
+		if res, ok := files[url]; !ok {
+			return nil, errors.New("err")
+		} else {
+			return res, nil
+		}
+	})
+	fakeFiles.ReadCalls(func(ctx context.Context, url string) ([]byte, error) {
+		if url == "https://test/docs/with-slug.md" {
+			return []byte("---\nslug: custom-slug\n---\n# With slug\n"), nil
+		}
+		return []byte("# Document\n"), nil
+	})
+	fakeR := &repositoryhostsfakes.FakeRegistry{}
+	fakeR.GetReturns(fakeFiles, nil)
+	return fakeR
+}
+
 //go:embed tests/examples/*
 var examples embed.FS
 
@@ -43,37 +79,13 @@ var _ = Describe("Manifest test", func() {
 				Expect(err).ToNot(HaveOccurred())
 				yaml.Unmarshal([]byte(resultBytes), &expected)
 
-				fakeFiles := &repositoryhostsfakes.FakeRepositoryHost{}
-				fakeFiles.ManifestFromURLCalls(func(url string) (string, error) {
-					url = strings.TrimPrefix(url, "https://test")
-					content, err := examples.ReadFile(url)
-					return string(content), err
-				})
-				fakeFiles.ToAbsLinkCalls(func(url, link string) (string, error) {
-					if strings.HasPrefix(link, "/") {
-						return "https://test" + link, nil
-					}
-					return link, nil
-				})
-				fakeFiles.FileTreeFromURLCalls(func(url string) ([]string, error) {
-					files := map[string][]string{}
-					files["https://test/website"] = []string{"blog/2023/_index.md"}
-					files["https://test/blogs"] = []string{"2023/one", "2023/two.md"}
-					if res, ok := files[url]; !ok {
-						return nil, errors.New("err")
-					} else {
-						return res, nil
-					}
-				})
-				fakeR := repositoryhostsfakes.FakeRegistry{}
-				fakeR.GetReturns(fakeFiles, nil)
-
-				allNodes, err := manifest.ResolveManifest(exampleFile, &fakeR)
+				allNodes, err := manifest.ResolveManifest(exampleFile, fakeRegistry())
 				Expect(err).ToNot(HaveOccurred())
 				files := []*manifest.Node{}
 				for _, node := range allNodes {
 					if node.Type == "file" {
 						node.RemoveParent()
+						node.RemoveProvenance()
 						files = append(files, node)
 					}
 				}
@@ -89,6 +101,90 @@ var _ = Describe("Manifest test", func() {
 			Entry("covering _index.md use cases", "_index_md_with_properties"),
 			Entry("covering fileTree use cases and dir merges", "filetree"),
 			Entry("covering manifest use cases", "manifest"),
+			Entry("covering name expressions", "name_expressions"),
+			Entry("covering default persona folders", "persona_folders"),
+			Entry("covering custom folder flattening", "folder_flattening"),
+			Entry("covering disabled folder flattening", "folder_flattening_disabled"),
+			Entry("covering manifest overlays", "overlay"),
+			Entry("covering inline content", "inline_content"),
+			Entry("covering transformers configuration", "transformers"),
+			Entry("covering source options", "source_options"),
+			Entry("covering front matter schemas", "frontmatter_schema"),
 		)
+		It("fails for folder flattening in an included manifest", func() {
+			_, err := manifest.ResolveManifest("tests/examples/folder_flattening_included.yaml", fakeRegistry())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("folderFlattening is only supported in the root manifest, found in tests/examples/folder_flattening_disabled.yaml"))
+		})
+		It("overrides the root manifest folder flattening with the options", func() {
+			allNodes, err := manifest.ResolveManifestWithOptions("tests/examples/persona_folders.yaml", fakeRegistry(), manifest.ParsingOptions{FolderFlattening: &manifest.FolderFlattening{Disabled: true}})
+			Expect(err).ToNot(HaveOccurred())
+			var paths []string
+			for _, node := range allNodes {
+				if node.Type == "file" {
+					paths = append(paths, node.NodePath())
+				}
+			}
+			Expect(paths).To(Equal([]string{"docs/a.md", "docs/usage/a.md"}))
+		})
+		It("fails for a patch matching no node", func() {
+			_, err := manifest.ResolveManifest("tests/examples/overlay_no_match.yaml", fakeRegistry())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("matches no node"))
+		})
+		It("fails for a file with more than one content", func() {
+			_, err := manifest.ResolveManifest("tests/examples/inline_content_conflict.yaml", fakeRegistry())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("can have only one of source, multiSource, content or template"))
+		})
+		It("fails for source options of another source", func() {
+			_, err := manifest.ResolveManifest("tests/examples/source_options_invalid.yaml", fakeRegistry())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("has source options for /docs/other.md which is not one of its sources"))
+		})
+		It("fails for a front matter key of unknown type", func() {
+			_, err := manifest.ResolveManifest("tests/examples/frontmatter_schema_invalid.yaml", fakeRegistry())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("node guide.md declares front matter key tags with unknown type array"))
+		})
+		It("records the provenance of nodes", func() {
+			allNodes, err := manifest.ResolveManifest("tests/examples/manifest.yaml", fakeRegistry())
+			Expect(err).ToNot(HaveOccurred())
+			provenance := map[string][]string{}
+			for _, node := range allNodes {
+				if node.Type == "file" {
+					provenance[node.Source] = []string{node.ManifestURL(), node.FileTreeURL()}
+				}
+			}
+			Expect(provenance).To(HaveKeyWithValue("https://test/blogs/2023/one", []string{"tests/examples/filetree.yaml", "https://test/blogs"}))
+			Expect(provenance).To(HaveKeyWithValue("https://test/blogs/2023/foo", []string{"tests/examples/filetree.yaml", ""}))
+			Expect(provenance).To(HaveKeyWithValue("https://foo.com/one/two/_index.md", []string{"tests/examples/_index_md_with_properties.yaml", ""}))
+		})
+		It("builds the resolved structure", func() {
+			allNodes, err := manifest.ResolveManifest("tests/examples/filetree.yaml", fakeRegistry())
+			Expect(err).ToNot(HaveOccurred())
+			resolved := allNodes[0].Resolved()
+			Expect(resolved).To(HaveLen(1))
+			Expect(resolved[0].Path).To(Equal("blog"))
+			Expect(resolved[0].Type).To(Equal("dir"))
+			var b strings.Builder
+			Expect(manifest.WriteResolvedTree(&b, resolved)).To(Succeed())
+			Expect(b.String()).To(ContainSubstring("    one.md <- https://test/blogs/2023/one (manifest: tests/examples/filetree.yaml, fileTree: https://test/blogs)\n"))
+		})
+		It("leaves the included manifests out of the resolved structure", func() {
+			allNodes, err := manifest.ResolveManifest("tests/examples/manifest.yaml", fakeRegistry())
+			Expect(err).ToNot(HaveOccurred())
+			var types []string
+			var collect func(nodes []*manifest.ResolvedNode)
+			collect = func(nodes []*manifest.ResolvedNode) {
+				for _, n := range nodes {
+					types = append(types, n.Type)
+					collect(n.Structure)
+				}
+			}
+			collect(allNodes[0].Resolved())
+			Expect(types).NotTo(BeEmpty())
+			Expect(types).NotTo(ContainElement("manifest"))
+		})
 	})
 })
@@ -13,12 +13,37 @@ type Manifest struct {
 
 // FileType represent a file node
 type FileType struct {
-	// File is the renaming of the file from source. If Source is empty then File should contain the url
+	// File is the renaming of the file from source. If Source is empty then File should contain the url.
+	// It can be a name expression evaluated against Source
 	File string `yaml:"file,omitempty"`
 	// Source is the source of file. If empty File must be the url
 	Source string `yaml:"source,omitempty"`
 	// MultiSource is a file build from multiple sources
 	MultiSource []string `yaml:"multiSource,omitempty"`
+	// SourceOptions configures the content of the sources by source
+	SourceOptions map[string]SourceOptions `yaml:"sourceOptions,omitempty"`
+	// Content is the literal markdown content of the file
+	Content string `yaml:"content,omitempty"`
+	// Template is a Go text/template rendering the markdown content of the file
+	Template string `yaml:"template,omitempty"`
+}
+
+// SourceOptions configures how the content of a source is added to a document
+type SourceOptions struct {
+	// DemoteHeadings is the number of levels the source headings are demoted by
+	DemoteHeadings int `yaml:"demoteHeadings,omitempty"`
+	// DropFirstH1 removes the first level 1 heading of the source
+	DropFirstH1 bool `yaml:"dropFirstH1,omitempty"`
+	// InsertTitle inserts a level 1 heading with the source title
+	InsertTitle bool `yaml:"insertTitle,omitempty"`
+}
+
+// FrontmatterKey declares a key of the document front matter
+type FrontmatterKey struct {
+	// Type of the value: string, number, boolean, list or map. Any type is allowed if empty
+	Type string `yaml:"type,omitempty"`
+	// Required fails the documents without the key
+	Required bool `yaml:"required,omitempty"`
 }
 
 // DirType represents a directory node
@@ -35,14 +60,24 @@ type FilesTreeType struct {
 	FileTree string `yaml:"fileTree,omitempty"`
 	// ExcludeFiles files to be excluded
 	ExcludeFiles []string `yaml:"excludeFiles,omitempty"`
+	// FileName is a name expression evaluated for each file of the tree
+	FileName string `yaml:"fileName,omitempty"`
 }
 
 // ManifType represents a manifest node
 type ManifType struct {
 	// Manifest is the manifest url
 	Manifest string `yaml:"manifest,omitempty"`
+	// FolderFlattening configures the folders which content is moved into their parent
+	FolderFlattening *FolderFlattening `yaml:"folderFlattening,omitempty"`
+	// Patches are applied to the structure of the included manifest
+	Patches []Patch `yaml:"patches,omitempty"`
+	// CollisionStrategy resolves files written to the same output path. Only applies to the root manifest
+	CollisionStrategy string `yaml:"collisionStrategy,omitempty"`
 
 	manifest *Manifest
+	// overlaid are the top level nodes of the included manifest that patches apply to
+	overlaid []*Node
 }
 
 // Node represents a generic mnifest node
@@ -59,10 +94,52 @@ type Node struct {
 	Properties map[string]interface{} `yaml:"properties,omitempty"`
 	// Frontmatter of the node
 	Frontmatter map[string]interface{} `yaml:"frontmatter,omitempty"`
+	// Transformers configures the document transformers by name. Child nodes inherit it
+	Transformers map[string]interface{} `yaml:"transformers,omitempty"`
+	// FrontmatterSchema declares the keys of the document front matter. Child nodes inherit it
+	FrontmatterSchema map[string]FrontmatterKey `yaml:"frontmatterSchema,omitempty"`
 	// Type of node
 	Type string `yaml:"type,omitempty"`
 	// Path of node
 	Path string `yaml:"path,omitempty"`
 	// Parent of node
 	parent *Node
+	// manifestURL is the URL of the manifest declaring the node
+	manifestURL string
+	// fileTreeURL is the URL of the fileTree the node is expanded from
+	fileTreeURL string
+}
+
+// FolderFlattening configures folder flattening
+type FolderFlattening struct {
+	// Disabled turns folder flattening off
+	Disabled bool `yaml:"disabled,omitempty" mapstructure:"disabled"`
+	// Rules replaces the default flattening rules
+	Rules []FlattenRule `yaml:"rules,omitempty" mapstructure:"rules"`
+}
+
+// FlattenRule describes a folder which content is moved into its parent
+type FlattenRule struct {
+	// Dir is the name of the flattened folder
+	Dir string `yaml:"dir" mapstructure:"dir"`
+	// Frontmatter is injected into the front matter of the moved nodes
+	Frontmatter map[string]interface{} `yaml:"frontmatter,omitempty" mapstructure:"frontmatter"`
+	// Alias is the pattern of the alias added to the moved nodes. Supports $path, $dir and $name variables
+	Alias string `yaml:"alias,omitempty" mapstructure:"alias"`
+	// Suffix is appended to the name of a moved file colliding with another file
+	Suffix string `yaml:"suffix,omitempty" mapstructure:"suffix"`
+}
+
+// Patch describes a change of an included manifest structure
+type Patch struct {
+	// Path targets the node with this output path
+	Path string `yaml:"path,omitempty"`
+	// Source targets the nodes with this source
+	Source string `yaml:"source,omitempty"`
+	// Op is the patch operation: remove, replace, setFrontmatter or insertAfter
+	Op string `yaml:"op"`
+	// Node is the node used by the replace and insertAfter operations
+	Node *Node `yaml:"node,omitempty"`
+	// Frontmatter is merged into the target front matter by the setFrontmatter operation
+	Frontmatter map[string]interface{} `yaml:"frontmatter,omitempty"`
 }
@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/gardener/docforge/pkg/frontmatter"
+	resourcehandlers "github.com/gardener/docforge/pkg/readers/repositoryhosts"
+	"github.com/google/uuid"
+)
+
+// nameVariables are the shorthand variables supported in name expressions
+var nameVariables = []string{"$name", "$ext", "$uuid"}
+
+// nameData holds the values available to name expressions
+type nameData struct {
+	// Name is the base name of the source without extension
+	Name string
+	// Ext is the extension of the source, including the leading dot
+	Ext string
+	// UUID is a stable identifier derived from the source URL
+	UUID string
+	// Frontmatter is the source front matter overridden by the node front matter
+	Frontmatter map[string]interface{}
+}
+
+// isNameExpression returns true if the name contains variables or a template action
+func isNameExpression(name string) bool {
+	if strings.Contains(name, "{{") {
+		return true
+	}
+	for _, v := range nameVariables {
+		if strings.Contains(name, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateName evaluates a name expression against a node source
+func evaluateName(expression string, source string, frontmatter map[string]interface{}, r resourcehandlers.Registry) (string, error) {
+	if source == "" {
+		return "", fmt.Errorf("name expression %s requires a source", expression)
+	}
+	u := strings.SplitN(source, "?", 2)[0]
+	u = strings.SplitN(u, "#", 2)[0]
+	ext := path.Ext(u)
+	data := nameData{
+		Name: strings.TrimSuffix(path.Base(u), ext),
+		Ext:  ext,
+		UUID: uuid.NewSHA1(uuid.NameSpaceURL, []byte(source)).String(),
+	}
+	name := strings.NewReplacer("$name", data.Name, "$ext", data.Ext, "$uuid", data.UUID).Replace(expression)
+	if !strings.Contains(name, "{{") {
+		return name, nil
+	}
+	if strings.Contains(name, ".Frontmatter") {
+		fm, err := sourceFrontmatter(source, r)
+		if err != nil {
+			return "", err
+		}
+		for k, v := range frontmatter {
+			fm[k] = v
+		}
+		data.Frontmatter = fm
+	}
+	tmpl, err := template.New(expression).Option("missingkey=error").Parse(name)
+	if err != nil {
+		return "", fmt.Errorf("invalid name expression %s : %w", expression, err)
+	}
+	var b bytes.Buffer
+	if err = tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("can't evaluate name expression %s for source %s : %w", expression, source, err)
+	}
+	if b.Len() == 0 || strings.Contains(b.String(), "/") {
+		return "", fmt.Errorf("name expression %s evaluates to invalid file name %q for source %s", expression, b.String(), source)
+	}
+	return b.String(), nil
+}
+
+// sourceFrontmatter reads the source and returns its front matter
+func sourceFrontmatter(source string, r resourcehandlers.Registry) (map[string]interface{}, error) {
+	fs, err := r.Get(source)
+	if err != nil {
+		return nil, err
+	}
+	content, err := fs.Read(context.TODO(), source)
+	if err != nil {
+		return nil, fmt.Errorf("can't read source %s : %w", source, err)
+	}
+	fm, _, err := frontmatter.Split(content)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse front matter of %s : %w", source, err)
+	}
+	if fm == nil {
+		fm = map[string]interface{}{}
+	}
+	return fm, nil
+}
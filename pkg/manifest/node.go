@@ -39,7 +39,12 @@ func (n *Node) HugoPrettyPath() string {
 
 // HasContent returns true if the node is a document node
 func (n *Node) HasContent() bool {
-	return len(n.MultiSource) > 0 || len(n.Source) > 0
+	return len(n.MultiSource) > 0 || len(n.Source) > 0 || n.IsInline()
+}
+
+// IsInline returns true if the node content is defined in the manifest
+func (n *Node) IsInline() bool {
+	return len(n.Content) > 0 || len(n.Template) > 0
 }
 
 // Parent is the node parent
@@ -47,6 +52,16 @@ func (n *Node) Parent() *Node {
 	return n.parent
 }
 
+// ManifestURL returns the URL of the manifest declaring the node
+func (n *Node) ManifestURL() string {
+	return n.manifestURL
+}
+
+// FileTreeURL returns the URL of the fileTree the node is expanded from, if any
+func (n *Node) FileTreeURL() string {
+	return n.fileTreeURL
+}
+
 func (n *Node) String() string {
 	node, err := yaml.Marshal(n)
 	if err != nil {
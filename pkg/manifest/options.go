@@ -8,4 +8,7 @@ package manifest
 type ParsingOptions struct {
 	ExtractedFilesFormats []string `mapstructure:"extracted-files-formats"`
 	Hugo                  bool     `mapstructure:"hugo"`
+	CollisionStrategy     string   `mapstructure:"collision-strategy"`
+	// FolderFlattening overrides the folder flattening of the root manifest. It is set in the configuration file
+	FolderFlattening *FolderFlattening `mapstructure:"folder-flattening"`
 }
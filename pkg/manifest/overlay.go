@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	resourcehandlers "github.com/gardener/docforge/pkg/readers/repositoryhosts"
+	"gopkg.in/yaml.v2"
+)
+
+// patch operations
+const (
+	patchRemove         = "remove"
+	patchReplace        = "replace"
+	patchSetFrontmatter = "setFrontmatter"
+	patchInsertAfter    = "insertAfter"
+)
+
+// patchTarget is a node matched by a patch and the node containing it
+type patchTarget struct {
+	node      *Node
+	container *Node
+}
+
+func applyOverlays(node *Node, parent *Node, manifest *Node, r resourcehandlers.Registry) error {
+	if node.Type != "manifest" || len(node.Patches) == 0 || parent == nil {
+		return nil
+	}
+	for i, patch := range node.Patches {
+		match, err := patch.matcher(manifest, r)
+		if err != nil {
+			return fmt.Errorf("patch %d of manifest %s : %w", i, node.Manifest, err)
+		}
+		targets := findPatchTargets(node.overlaid, parent, match)
+		if len(targets) == 0 {
+			return fmt.Errorf("patch %d of manifest %s targeting %s%s matches no node", i, node.Manifest, patch.Path, patch.Source)
+		}
+		for _, target := range targets {
+			if err = node.applyPatch(patch, target, parent, manifest, r); err != nil {
+				return fmt.Errorf("patch %d of manifest %s : %w", i, node.Manifest, err)
+			}
+		}
+	}
+	return nil
+}
+
+// matcher returns a function matching the nodes targeted by the patch
+func (p Patch) matcher(manifest *Node, r resourcehandlers.Registry) (func(*Node) bool, error) {
+	switch {
+	case p.Path != "" && p.Source != "":
+		return nil, fmt.Errorf("patch can't target both path %s and source %s", p.Path, p.Source)
+	case p.Path != "":
+		target := strings.Trim(path.Clean(p.Path), "/")
+		return func(n *Node) bool {
+			nodePath := n.NodePath()
+			return nodePath == target || (n.Type == "file" && nodePath == target+".md")
+		}, nil
+	case p.Source != "":
+		fs, err := r.Get(manifest.Manifest)
+		if err != nil {
+			return nil, err
+		}
+		source, err := fs.ToAbsLink(manifest.Manifest, p.Source)
+		if err != nil {
+			return nil, fmt.Errorf("can't build patch target absolute link %s : %w", p.Source, err)
+		}
+		return func(n *Node) bool {
+			return n.Source == source || slices.Contains(n.MultiSource, source)
+		}, nil
+	default:
+		return nil, fmt.Errorf("patch has no path or source target")
+	}
+}
+
+// findPatchTargets returns the nodes in the given subtrees matched by a patch
+func findPatchTargets(nodes []*Node, container *Node, match func(*Node) bool) []patchTarget {
+	var targets []patchTarget
+	for _, n := range nodes {
+		if match(n) {
+			targets = append(targets, patchTarget{node: n, container: container})
+		}
+		targets = append(targets, findPatchTargets(n.Structure, n, match)...)
+	}
+	return targets
+}
+
+// applyPatch applies a patch to a target of an overlay node, included in parent
+func (n *Node) applyPatch(patch Patch, target patchTarget, parent *Node, manifest *Node, r resourcehandlers.Registry) error {
+	// the overlaid nodes are kept in sync with top level changes
+	edit := func(f func([]*Node) []*Node) {
+		target.container.Structure = f(target.container.Structure)
+		if target.container == parent {
+			n.overlaid = f(n.overlaid)
+		}
+	}
+	switch patch.Op {
+	case patchRemove:
+		edit(func(nodes []*Node) []*Node {
+			return slices.DeleteFunc(nodes, func(c *Node) bool { return c == target.node })
+		})
+	case patchSetFrontmatter:
+		if target.node.Frontmatter == nil {
+			target.node.Frontmatter = map[string]interface{}{}
+		}
+		for k, v := range patch.Frontmatter {
+			target.node.Frontmatter[k] = v
+		}
+	case patchReplace, patchInsertAfter:
+		if patch.Node == nil {
+			return fmt.Errorf("%s patch requires a node", patch.Op)
+		}
+		newNode, err := copyNode(patch.Node)
+		if err != nil {
+			return err
+		}
+		edit(func(nodes []*Node) []*Node {
+			i := slices.Index(nodes, target.node)
+			if i < 0 {
+				return nodes
+			}
+			if patch.Op == patchReplace {
+				nodes[i] = newNode
+				return nodes
+			}
+			return slices.Insert(nodes, i+1, newNode)
+		})
+		if err = resolvePatchNode(newNode, target.container, manifest, r); err != nil {
+			return err
+		}
+		if patch.Op == patchReplace && newNode.Type == "dir" && len(newNode.Structure) == 0 {
+			// replacing a dir with no structure renames it
+			newNode.Structure = target.node.Structure
+		}
+	default:
+		return fmt.Errorf("unknown patch operation %q", patch.Op)
+	}
+	return nil
+}
+
+// resolvePatchNode resolves a node inserted by a patch the same way as the manifest nodes
+func resolvePatchNode(node *Node, container *Node, manifest *Node, r resourcehandlers.Registry) error {
+	for _, f := range []nodeTransformation{decideNodeType, setManifestURL, calculatePath, resolveRelativeLinks, extractFilesFromNode} {
+		if err := processManifest(f, node, container, manifest, r); err != nil {
+			return err
+		}
+		if node.Type == "manifest" {
+			return fmt.Errorf("patch node \n\n%s\ncan't include a manifest", node)
+		}
+	}
+	return nil
+}
+
+// copyNode returns a deep copy of a node
+func copyNode(node *Node) (*Node, error) {
+	content, err := yaml.Marshal(node)
+	if err != nil {
+		return nil, err
+	}
+	out := &Node{}
+	if err = yaml.Unmarshal(content, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
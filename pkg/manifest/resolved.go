@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ResolvedNode is the machine-readable view of a resolved node
+type ResolvedNode struct {
+	// Path is the output path of the node
+	Path string `json:"path" yaml:"path"`
+	// Type is the node type, file or dir
+	Type string `json:"type" yaml:"type"`
+	// Sources are the URLs of the node content
+	Sources []string `json:"sources,omitempty" yaml:"sources,omitempty"`
+	// Manifest is the URL of the manifest declaring the node
+	Manifest string `json:"manifest,omitempty" yaml:"manifest,omitempty"`
+	// FileTree is the URL of the fileTree the node is expanded from
+	FileTree string `json:"fileTree,omitempty" yaml:"fileTree,omitempty"`
+	// Frontmatter is the front matter of the node in the manifests, with the front matter propagated from its
+	// parents. The front matter of the source documents is merged when they are processed and isn't included
+	Frontmatter map[string]interface{} `json:"frontmatter,omitempty" yaml:"frontmatter,omitempty"`
+	// Structure are the child nodes
+	Structure []*ResolvedNode `json:"structure,omitempty" yaml:"structure,omitempty"`
+}
+
+// Resolved returns the resolved view of the file and dir nodes structured under n
+func (n *Node) Resolved() []*ResolvedNode {
+	out := []*ResolvedNode{}
+	for _, child := range n.Structure {
+		// the content of included manifests is moved into their parent
+		if child.Type == "manifest" {
+			continue
+		}
+		out = append(out, child.resolved())
+	}
+	return out
+}
+
+func (n *Node) resolved() *ResolvedNode {
+	out := &ResolvedNode{
+		Path:     n.NodePath(),
+		Type:     n.Type,
+		Manifest: n.manifestURL,
+		FileTree: n.fileTreeURL,
+	}
+	if n.Source != "" {
+		out.Sources = append(out.Sources, n.Source)
+	}
+	out.Sources = append(out.Sources, n.MultiSource...)
+	if len(n.Frontmatter) > 0 {
+		out.Frontmatter = stringKeys(n.Frontmatter).(map[string]interface{})
+	}
+	out.Structure = n.Resolved()
+	if len(out.Structure) == 0 {
+		out.Structure = nil
+	}
+	return out
+}
+
+// stringKeys converts the nested yaml maps to maps with string keys
+func stringKeys(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, e := range t {
+			m[k] = stringKeys(e)
+		}
+		return m
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, e := range t {
+			m[fmt.Sprint(k)] = stringKeys(e)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(t))
+		for i, e := range t {
+			s[i] = stringKeys(e)
+		}
+		return s
+	default:
+		return v
+	}
+}
+
+// WriteResolvedTree writes the resolved nodes as an indented tree
+func WriteResolvedTree(w io.Writer, nodes []*ResolvedNode) error {
+	return writeResolvedTree(w, nodes, 0)
+}
+
+func writeResolvedTree(w io.Writer, nodes []*ResolvedNode, depth int) error {
+	for _, n := range nodes {
+		name := n.Path[strings.LastIndex(n.Path, "/")+1:]
+		line := strings.Repeat("  ", depth) + name
+		if n.Type == "dir" {
+			line += "/"
+		}
+		if len(n.Sources) > 0 {
+			line += " <- " + strings.Join(n.Sources, ", ")
+		}
+		var origin []string
+		if n.Manifest != "" {
+			origin = append(origin, "manifest: "+n.Manifest)
+		}
+		if n.FileTree != "" {
+			origin = append(origin, "fileTree: "+n.FileTree)
+		}
+		if len(origin) > 0 {
+			line += " (" + strings.Join(origin, ", ") + ")"
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+		if err := writeResolvedTree(w, n.Structure, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
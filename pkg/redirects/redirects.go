@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redirects
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/gardener/docforge/cmd/hugo"
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/workers/linkresolver"
+)
+
+const (
+	// Netlify writes a Netlify `_redirects` file
+	Netlify = "netlify"
+	// Nginx writes the entries of an nginx `map` block to be included
+	Nginx = "nginx"
+	// Apache writes an Apache `.htaccess` file
+	Apache = "apache"
+	// JSON writes a JSON object mapping aliases to targets
+	JSON = "json"
+)
+
+// formatFileNames are the default redirect map file names per format
+var formatFileNames = map[string]string{
+	Netlify: "_redirects",
+	Nginx:   "redirects.map",
+	Apache:  ".htaccess",
+	JSON:    "redirects.json",
+}
+
+// Redirect redirects an alias URL to the URL of a node
+type Redirect struct {
+	// From is the alias URL
+	From string
+	// To is the node URL
+	To string
+	// Node is the node declaring the alias
+	Node *manifest.Node
+}
+
+// Validate checks the redirect map format
+func Validate(format string) error {
+	if _, ok := formatFileNames[format]; !ok {
+		return fmt.Errorf("unsupported redirects format %s, must be one of: %s, %s, %s or %s", format, Netlify, Nginx, Apache, JSON)
+	}
+	return nil
+}
+
+// FileName returns the default file name of the redirect map format
+func FileName(format string) string {
+	return formatFileNames[format]
+}
+
+// Collect returns the redirects of the file nodes `aliases` front matter, and of the documentAliases of
+// their documents, to the page URLs of links, sorted by alias. Aliases declared more than once or shadowing a node URL are skipped and reported
+func Collect(nodes []*manifest.Node, documentAliases map[*manifest.Node][]interface{}, links *linkresolver.LinkResolver) ([]Redirect, []string) {
+	var (
+		redirects []Redirect
+		problems  []string
+	)
+	urls := map[string]*manifest.Node{}
+	for _, node := range nodes {
+		if node.Type == "file" {
+			urls[links.PageURL(node)] = node
+		}
+	}
+	declared := map[string]Redirect{}
+	for _, node := range nodes {
+		if node.Type != "file" {
+			continue
+		}
+		aliases, _ := node.Frontmatter["aliases"].([]interface{})
+		aliases = append(slices.Clone(aliases), documentAliases[node]...)
+		to := links.PageURL(node)
+		for _, alias := range aliases {
+			from := aliasURL(fmt.Sprint(alias), node, links.Hugo)
+			if r, ok := declared[from]; from == to || ok && r.Node == node {
+				continue
+			}
+			if target, ok := urls[from]; ok {
+				problems = append(problems, fmt.Sprintf("alias %s of %s shadows the URL of %s", from, node.NodePath(), target.NodePath()))
+				continue
+			}
+			if r, ok := declared[from]; ok {
+				if r.To == to {
+					problems = append(problems, fmt.Sprintf("duplicate alias %s of %s", from, node.NodePath()))
+				} else {
+					problems = append(problems, fmt.Sprintf("alias %s of %s conflicts with the same alias of %s, redirecting to %s", from, node.NodePath(), r.Node.NodePath(), r.To))
+				}
+				continue
+			}
+			declared[from] = Redirect{From: from, To: to, Node: node}
+		}
+	}
+	for _, r := range declared {
+		redirects = append(redirects, r)
+	}
+	slices.SortFunc(redirects, func(a, b Redirect) int { return strings.Compare(a.From, b.From) })
+	return redirects, problems
+}
+
+// aliasURL returns the root-relative URL of an alias. Relative aliases are relative to the node path
+func aliasURL(alias string, node *manifest.Node, hugo hugo.Hugo) string {
+	if !strings.HasPrefix(alias, "/") {
+		alias = path.Join(node.Path, alias)
+	}
+	return fmt.Sprintf("/%s/", strings.ToLower(strings.Trim(path.Join(hugo.BaseURL, alias), "/")))
+}
+
+// Write writes the redirects in the given format
+func Write(w io.Writer, format string, redirects []Redirect) error {
+	if err := Validate(format); err != nil {
+		return err
+	}
+	if format == JSON {
+		m := map[string]string{}
+		for _, r := range redirects {
+			m[r.From] = r.To
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(m)
+	}
+	for _, r := range redirects {
+		var line string
+		switch format {
+		case Netlify:
+			line = fmt.Sprintf("%s %s 301", r.From, r.To)
+		case Nginx:
+			line = fmt.Sprintf("%s %s;", r.From, r.To)
+		case Apache:
+			line = fmt.Sprintf("Redirect 301 %s %s", r.From, r.To)
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
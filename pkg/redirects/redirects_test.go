@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redirects_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gardener/docforge/cmd/docusaurus"
+	"github.com/gardener/docforge/cmd/html"
+	"github.com/gardener/docforge/cmd/hugo"
+	"github.com/gardener/docforge/cmd/mkdocs"
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/redirects"
+	"github.com/gardener/docforge/pkg/workers/linkresolver"
+	"github.com/gardener/docforge/pkg/writers/writersfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+func TestRedirects(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Redirects Suite")
+}
+
+func file(dir string, name string, aliases ...interface{}) *manifest.Node {
+	node := &manifest.Node{FileType: manifest.FileType{File: name}, Type: "file", Path: dir}
+	if len(aliases) > 0 {
+		node.Frontmatter = map[string]interface{}{"aliases": aliases}
+	}
+	return node
+}
+
+var _ = Describe("Redirects", func() {
+	var (
+		nodes           []*manifest.Node
+		documentAliases map[*manifest.Node][]interface{}
+		links           *linkresolver.LinkResolver
+		redirectz       []redirects.Redirect
+		problems        []string
+	)
+
+	BeforeEach(func() {
+		links = &linkresolver.LinkResolver{Hugo: hugo.Hugo{Enabled: true}}
+		nodes = []*manifest.Node{
+			{Type: "dir", DirType: manifest.DirType{Dir: "docs"}, Frontmatter: map[string]interface{}{"aliases": []interface{}{"/old/"}}},
+			file("docs", "usage.md", "/docs/usage/usage/"),
+			file("docs", "guide.md", "old-guide", "/Guide/"),
+			file("docs", "_index.md", "/docs/"),
+		}
+		documentAliases = nil
+	})
+
+	JustBeforeEach(func() {
+		redirectz, problems = redirects.Collect(nodes, documentAliases, links)
+	})
+
+	It("collects the file node aliases sorted by alias", func() {
+		Expect(problems).To(BeEmpty())
+		Expect(redirectz).To(HaveLen(3))
+		Expect(redirectz[0].From).To(Equal("/docs/old-guide/"))
+		Expect(redirectz[0].To).To(Equal("/docs/guide/"))
+		Expect(redirectz[1].From).To(Equal("/docs/usage/usage/"))
+		Expect(redirectz[1].To).To(Equal("/docs/usage/"))
+		Expect(redirectz[2].From).To(Equal("/guide/"))
+		Expect(redirectz[2].To).To(Equal("/docs/guide/"))
+		Expect(redirectz[2].Node).To(Equal(nodes[2]))
+	})
+
+	When("aliases are declared more than once", func() {
+		BeforeEach(func() {
+			nodes = append(nodes,
+				file("docs", "guide.md", "/guide/"),
+				file("other", "guide.md", "/guide/"),
+				file("other", "intro.md", "/docs/usage/"),
+			)
+		})
+
+		It("reports duplicates and conflicts", func() {
+			Expect(redirectz).To(HaveLen(3))
+			Expect(redirectz[2].To).To(Equal("/docs/guide/"))
+			Expect(problems).To(ConsistOf(
+				"duplicate alias /guide/ of docs/guide.md",
+				"alias /guide/ of other/guide.md conflicts with the same alias of docs/guide.md, redirecting to /docs/guide/",
+				"alias /docs/usage/ of other/intro.md shadows the URL of docs/usage.md",
+			))
+		})
+	})
+
+	When("documents declare aliases", func() {
+		BeforeEach(func() {
+			documentAliases = map[*manifest.Node][]interface{}{nodes[1]: {"/docs/usage/usage/", "/howto/"}}
+		})
+
+		It("collects them with the node aliases", func() {
+			Expect(problems).To(BeEmpty())
+			Expect(redirectz).To(HaveLen(4))
+			Expect(redirectz[3].From).To(Equal("/howto/"))
+			Expect(redirectz[3].To).To(Equal("/docs/usage/"))
+		})
+	})
+
+	When("a hugo base URL is set", func() {
+		BeforeEach(func() {
+			links.Hugo.BaseURL = "base"
+			nodes = nodes[2:3]
+		})
+
+		It("prefixes the aliases", func() {
+			Expect(redirectz).To(HaveLen(2))
+			Expect(redirectz[0].From).To(Equal("/base/docs/old-guide/"))
+			Expect(redirectz[0].To).To(Equal("/base/docs/guide/"))
+		})
+	})
+
+	DescribeTable("redirecting to the page URLs of the output modes",
+		func(lr *linkresolver.LinkResolver, index string, expected map[string]string) {
+			nodes = []*manifest.Node{file("docs", index, "/overview/"), file("docs", "guide.md", "old-guide")}
+			redirectz, problems = redirects.Collect(nodes, nil, lr)
+			Expect(problems).To(BeEmpty())
+			targets := map[string]string{}
+			for _, r := range redirectz {
+				targets[r.From] = r.To
+			}
+			Expect(targets).To(Equal(expected))
+		},
+		Entry("mkdocs", &linkresolver.LinkResolver{MkDocs: mkdocs.MkDocs{Enabled: true}}, "index.md",
+			map[string]string{"/overview/": "/docs/", "/docs/old-guide/": "/docs/guide/"}),
+		Entry("docusaurus", &linkresolver.LinkResolver{Docusaurus: docusaurus.Docusaurus{Enabled: true}, Hugo: hugo.Hugo{IndexFileNames: []string{"readme.md"}}}, "README.md",
+			map[string]string{"/overview/": "/docs/", "/docs/old-guide/": "/docs/guide/"}),
+		Entry("html", &linkresolver.LinkResolver{HTML: html.HTML{Enabled: true}}, "index.md",
+			map[string]string{"/overview/": "/docs/index.html", "/docs/old-guide/": "/docs/guide.html"}),
+	)
+
+	DescribeTable("writing redirect maps",
+		func(format string, expected string, expectedErr error) {
+			var b bytes.Buffer
+			redirectMap := []redirects.Redirect{{From: "/a/", To: "/b/"}, {From: "/c/", To: "/d/"}}
+			err := redirects.Write(&b, format, redirectMap)
+			if expectedErr != nil {
+				Expect(err).To(MatchError(expectedErr))
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b.String()).To(Equal(expected))
+		},
+		Entry("netlify", redirects.Netlify, "/a/ /b/ 301\n/c/ /d/ 301\n", nil),
+		Entry("nginx", redirects.Nginx, "/a/ /b/;\n/c/ /d/;\n", nil),
+		Entry("apache", redirects.Apache, "Redirect 301 /a/ /b/\nRedirect 301 /c/ /d/\n", nil),
+		Entry("json", redirects.JSON, "{\n  \"/a/\": \"/b/\",\n  \"/c/\": \"/d/\"\n}\n", nil),
+		Entry("unsupported", "caddy", "", redirects.Validate("caddy")),
+	)
+
+	Describe("writer", func() {
+		var (
+			writer          *writersfakes.FakeWriter
+			redirectsWriter *writersfakes.FakeWriter
+			sut             *redirects.Writer
+			err             error
+		)
+
+		BeforeEach(func() {
+			writer = &writersfakes.FakeWriter{}
+			redirectsWriter = &writersfakes.FakeWriter{}
+			sut, err = redirects.New(writer, redirectsWriter, redirects.Netlify, "", links)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("writes the redirects of the written documents aliases", func() {
+			Expect(sut.Write("usage.md", "docs", []byte("---\naliases:\n- /howto/\n---\n# Usage\n"), nodes[1])).To(Succeed())
+			Expect(writer.WriteCallCount()).To(Equal(1))
+			problems, err := sut.Generate(nodes)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(problems).To(BeEmpty())
+			Expect(redirectsWriter.WriteCallCount()).To(Equal(1))
+			name, path, content, node := redirectsWriter.WriteArgsForCall(0)
+			Expect(name).To(Equal("_redirects"))
+			Expect(path).To(Equal("."))
+			Expect(string(content)).To(Equal("/docs/old-guide/ /docs/guide/ 301\n/docs/usage/usage/ /docs/usage/ 301\n/guide/ /docs/guide/ 301\n/howto/ /docs/usage/ 301\n"))
+			Expect(node).To(BeNil())
+		})
+
+		It("fails on unsupported formats", func() {
+			_, err = redirects.New(writer, redirectsWriter, "caddy", "", links)
+			Expect(err).To(MatchError(redirects.Validate("caddy")))
+		})
+	})
+})
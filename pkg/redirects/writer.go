@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redirects
+
+import (
+	"bytes"
+	"path"
+	"sync"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/workers/document/frontmatter"
+	"github.com/gardener/docforge/pkg/workers/linkresolver"
+	"github.com/gardener/docforge/pkg/writers"
+)
+
+// Writer generates the redirect map of the node aliases. It decorates the writer of the
+// documents and records the aliases in their front matter as written
+type Writer struct {
+	writer          writers.Writer
+	redirectsWriter writers.Writer
+	format          string
+	file            string
+	links           *linkresolver.LinkResolver
+
+	mux     sync.Mutex
+	aliases map[*manifest.Node][]interface{}
+}
+
+// New creates a redirects Writer decorating writer. The redirect map is written in the format with
+// redirectsWriter to file, or to the default file name of the format when file is empty. The redirects
+// target the page URLs of links
+func New(writer writers.Writer, redirectsWriter writers.Writer, format string, file string, links *linkresolver.LinkResolver) (*Writer, error) {
+	if err := Validate(format); err != nil {
+		return nil, err
+	}
+	if file == "" {
+		file = FileName(format)
+	}
+	return &Writer{
+		writer:          writer,
+		redirectsWriter: redirectsWriter,
+		format:          format,
+		file:            file,
+		links:           links,
+		aliases:         map[*manifest.Node][]interface{}{},
+	}, nil
+}
+
+// Write records the `aliases` front matter of the written document and writes it with the decorated writer
+func (w *Writer) Write(name, path string, docBlob []byte, node *manifest.Node) error {
+	if node != nil && node.Type == "file" {
+		if aliases, ok := frontmatter.Parse(docBlob)["aliases"].([]interface{}); ok {
+			w.mux.Lock()
+			w.aliases[node] = aliases
+			w.mux.Unlock()
+		}
+	}
+	return w.writer.Write(name, path, docBlob, node)
+}
+
+// Generate writes the redirect map of the nodes and returns the problems of their aliases
+func (w *Writer) Generate(nodes []*manifest.Node) ([]string, error) {
+	w.mux.Lock()
+	redirects, problems := Collect(nodes, w.aliases, w.links)
+	w.mux.Unlock()
+	var b bytes.Buffer
+	if err := Write(&b, w.format, redirects); err != nil {
+		return problems, err
+	}
+	return problems, w.redirectsWriter.Write(path.Base(w.file), path.Dir(w.file), b.Bytes(), nil)
+}
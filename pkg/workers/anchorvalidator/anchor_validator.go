@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package anchorvalidator
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate -header ../../../license_prefix.txt
+
+// Interface validates the fragment links of the processed documents
+//
+//counterfeiter:generate . Interface
+type Interface interface {
+	// AddAnchors records the anchors of the document published at target
+	AddAnchors(target string, anchors []string)
+	// AddLink records a fragment link
+	AddLink(link Link)
+	// Validate returns the problems of the recorded links
+	Validate() []string
+}
+
+// Link is a fragment link of a processed document
+type Link struct {
+	// Source is the URI of the document source containing the link
+	Source string
+	// Line is the line of the link in the document source
+	Line int
+	// Target is the URL of the linked document
+	Target string
+	// Fragment is the linked anchor
+	Fragment string
+}
+
+// Validator collects the anchors and the fragment links of the processed documents
+// and reports the links to anchors missing in their target document
+type Validator struct {
+	mux     sync.Mutex
+	anchors map[string]map[string]struct{}
+	links   []Link
+}
+
+// New creates a new Validator
+func New() *Validator {
+	return &Validator{
+		anchors: map[string]map[string]struct{}{},
+	}
+}
+
+// AddAnchors records the anchors of the document published at target
+func (v *Validator) AddAnchors(target string, anchors []string) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+	set, ok := v.anchors[target]
+	if !ok {
+		set = map[string]struct{}{}
+		v.anchors[target] = set
+	}
+	for _, a := range anchors {
+		set[a] = struct{}{}
+	}
+}
+
+// AddLink records a fragment link
+func (v *Validator) AddLink(link Link) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+	v.links = append(v.links, link)
+}
+
+// Validate returns the problems of the recorded links sorted by source and line.
+// Links to documents that are not processed are not validated
+func (v *Validator) Validate() []string {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+	links := make([]Link, len(v.links))
+	copy(links, v.links)
+	sort.SliceStable(links, func(i, j int) bool {
+		if links[i].Source != links[j].Source {
+			return links[i].Source < links[j].Source
+		}
+		return links[i].Line < links[j].Line
+	})
+	var problems []string
+	for _, l := range links {
+		anchors, ok := v.anchors[l.Target]
+		if !ok {
+			continue
+		}
+		if _, ok = anchors[l.Fragment]; !ok {
+			problems = append(problems, fmt.Sprintf("broken anchor #%s in %s:%d: %s has no such anchor", l.Fragment, l.Source, l.Line, l.Target))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package anchorvalidator_test
+
+import (
+	"testing"
+
+	"github.com/gardener/docforge/pkg/workers/anchorvalidator"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestAnchorValidator(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Anchor Validator Suite")
+}
+
+var _ = Describe("Anchor validator", func() {
+	It("reports the links to missing anchors", func() {
+		v := anchorvalidator.New()
+		v.AddAnchors("/docs/a/", []string{"install", "usage"})
+		v.AddAnchors("/docs/b/", []string{"overview"})
+		v.AddLink(anchorvalidator.Link{Source: "https://github.com/o/r/blob/master/b.md", Line: 7, Target: "/docs/a/", Fragment: "setup"})
+		v.AddLink(anchorvalidator.Link{Source: "https://github.com/o/r/blob/master/a.md", Line: 3, Target: "/docs/b/", Fragment: "overview"})
+		v.AddLink(anchorvalidator.Link{Source: "https://github.com/o/r/blob/master/a.md", Line: 9, Target: "/docs/a/", Fragment: "configure"})
+		v.AddLink(anchorvalidator.Link{Source: "https://github.com/o/r/blob/master/a.md", Line: 1, Target: "https://example.com/page", Fragment: "top"})
+		Expect(v.Validate()).To(Equal([]string{
+			"broken anchor #configure in https://github.com/o/r/blob/master/a.md:9: /docs/a/ has no such anchor",
+			"broken anchor #setup in https://github.com/o/r/blob/master/b.md:7: /docs/a/ has no such anchor",
+		}))
+	})
+})
@@ -10,20 +10,29 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"path/filepath"
 	"strings"
 	"sync"
+	"text/template"
 
+	"github.com/gardener/docforge/cmd/docusaurus"
+	"github.com/gardener/docforge/cmd/html"
 	"github.com/gardener/docforge/cmd/hugo"
+	"github.com/gardener/docforge/cmd/mkdocs"
 	"github.com/gardener/docforge/pkg/manifest"
 	"github.com/gardener/docforge/pkg/readers/link"
 	"github.com/gardener/docforge/pkg/readers/repositoryhosts"
+	"github.com/gardener/docforge/pkg/workers/anchorvalidator"
 	"github.com/gardener/docforge/pkg/workers/document/frontmatter"
 	"github.com/gardener/docforge/pkg/workers/document/markdown"
+	"github.com/gardener/docforge/pkg/workers/document/transformer"
 	"github.com/gardener/docforge/pkg/workers/downloader"
 	"github.com/gardener/docforge/pkg/workers/linkresolver"
 	"github.com/gardener/docforge/pkg/workers/linkvalidator"
+	"github.com/gardener/docforge/pkg/workers/searchindex"
 	"github.com/gardener/docforge/pkg/writers"
 	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
 	"k8s.io/klog/v2"
 )
 
@@ -32,13 +41,26 @@ type Worker struct {
 	linkresolver linkresolver.Interface
 	downloader   downloader.Interface
 	validator    linkvalidator.Interface
+	anchors      anchorvalidator.Interface
+	search       searchindex.Interface
 
 	writer writers.Writer
 
 	resourcesRoot string
 
 	Repositoryhosts repositoryhosts.Registry
-	Hugo            hugo.Hugo
+	Transformers    *transformer.Registry
+	Options
+}
+
+// Options are the output modes the documents are written for
+type Options struct {
+	Hugo       hugo.Hugo
+	MkDocs     mkdocs.MkDocs
+	Docusaurus docusaurus.Docusaurus
+	HTML       html.HTML
+	// ShortcodeParams are the link-bearing parameters of the Hugo shortcodes
+	ShortcodeParams markdown.ShortcodeParams
 }
 
 // docContent defines a document content
@@ -46,18 +68,31 @@ type docContent struct {
 	docAst ast.Node
 	docCnt []byte
 	docURI string
+	// headingOffset demotes the rendered headings
+	headingOffset int
+	// headings are the source headings with their anchors in the source
+	headings []markdown.Heading
+	// droppedID is the anchor of the dropped first H1
+	droppedID string
+	// title is the inserted title heading
+	title *ast.Heading
+	// anchors maps the source anchors to the anchors in the document
+	anchors map[string]string
 }
 
 // NewDocumentWorker creates Worker objects
-func NewDocumentWorker(resourcesRoot string, downloader downloader.Interface, validator linkvalidator.Interface, linkResolver linkresolver.Interface, rh repositoryhosts.Registry, hugo hugo.Hugo, writer writers.Writer) *Worker {
+func NewDocumentWorker(resourcesRoot string, downloader downloader.Interface, validator linkvalidator.Interface, anchors anchorvalidator.Interface, search searchindex.Interface, linkResolver linkresolver.Interface, rh repositoryhosts.Registry, options Options, writer writers.Writer) *Worker {
 	return &Worker{
-		linkResolver,
-		downloader,
-		validator,
-		writer,
-		resourcesRoot,
-		rh,
-		hugo,
+		linkresolver:    linkResolver,
+		downloader:      downloader,
+		validator:       validator,
+		anchors:         anchors,
+		search:          search,
+		writer:          writer,
+		resourcesRoot:   resourcesRoot,
+		Repositoryhosts: rh,
+		Transformers:    transformer.DefaultRegistry,
+		Options:         options,
 	}
 }
 
@@ -86,6 +121,9 @@ func (d *Worker) ProcessNode(ctx context.Context, node *manifest.Node) error {
 			return nil
 		}
 		cnt = bytesBuff.Bytes()
+	} else if err := validateFrontmatter(node.Frontmatter, node); err != nil {
+		// the front matter of nodes without content is written as it is
+		return err
 	}
 	if err := d.writer.Write(node.Name(), node.Path, cnt, node); err != nil {
 		return err
@@ -98,14 +136,21 @@ func (d *Worker) process(ctx context.Context, b *bytes.Buffer, n *manifest.Node)
 	var fullContent []*docContent
 	nodePath := n.NodePath()
 	if len(n.Source) > 0 {
-		nc, err := d.processSource(ctx, "source", n.Source, nodePath)
+		nc, err := d.processSource(ctx, "source", n.Source, nodePath, n.SourceOptions[n.Source])
 		if err != nil {
 			return err
 		}
 		fullContent = append(fullContent, nc)
 	}
 	for _, src := range n.MultiSource {
-		nc, err := d.processSource(ctx, "multiSource", src, nodePath)
+		nc, err := d.processSource(ctx, "multiSource", src, nodePath, n.SourceOptions[src])
+		if err != nil {
+			return err
+		}
+		fullContent = append(fullContent, nc)
+	}
+	if n.IsInline() {
+		nc, err := d.processInline(n)
 		if err != nil {
 			return err
 		}
@@ -116,6 +161,7 @@ func (d *Worker) process(ctx context.Context, b *bytes.Buffer, n *manifest.Node)
 		return nil
 	}
 
+	var docFrontmatter map[string]interface{}
 	if fullContent[0].docAst.Kind() == ast.KindDocument {
 		firstDoc := fullContent[0].docAst.(*ast.Document)
 		docs := []frontmatter.NodeMeta{}
@@ -126,16 +172,49 @@ func (d *Worker) process(ctx context.Context, b *bytes.Buffer, n *manifest.Node)
 		}
 		frontmatter.MoveMultiSourceFrontmatterToTopDocument(docs)
 		frontmatter.MergeDocumentAndNodeFrontmatter(firstDoc, n)
-		frontmatter.ComputeNodeTitle(firstDoc, n, d.Hugo.IndexFileNames, d.Hugo.Enabled)
+		d.computeTitle(firstDoc, fullContent[0], n)
+		if d.Docusaurus.Enabled {
+			frontmatter.SetDocusaurusMeta(firstDoc, n)
+		}
+		docFrontmatter = firstDoc.Meta()
 	}
-	// 2. - write node content
+	rewriteAnchors(fullContent)
+	// 2. - transform node content
+	contents := make([]*transformer.Content, 0, len(fullContent))
+	for _, cnt := range fullContent {
+		contents = append(contents, &transformer.Content{Doc: cnt.docAst, Source: cnt.docCnt, SourceURI: cnt.docURI, HeadingOffset: cnt.headingOffset})
+	}
+	if err := d.Transformers.Transform(contents, &transformer.Context{Node: n, Frontmatter: docFrontmatter, Hugo: d.Hugo}); err != nil {
+		return err
+	}
+	// the schema applies to the front matter as rewritten by the transformers
+	if fullContent[0].docAst.Kind() == ast.KindDocument {
+		if err := validateFrontmatter(docFrontmatter, n); err != nil {
+			return err
+		}
+	}
+	if d.search != nil {
+		d.indexDocument(n, docFrontmatter, fullContent)
+	}
+	if d.anchors != nil {
+		d.collectAnchors(n, fullContent)
+	}
+	// 3. - write node content
 	for _, cnt := range fullContent {
 		lrt := linkResolverTask{
 			*d,
 			n,
 			cnt.docURI,
+			cnt.anchors,
+		}
+		opts := []renderer.Option{markdown.WithLinkResolver(lrt.resolveLink), markdown.WithHeadingOffset(cnt.headingOffset), markdown.WithSnippetResolver(d.snippetResolver(ctx, cnt.docURI)), markdown.WithShortcodeParams(d.ShortcodeParams), markdown.WithContentLinkResolver(lrt.resolveContentLink)}
+		if d.Docusaurus.Enabled {
+			opts = append(opts, markdown.WithMDX())
+		}
+		rnd := markdown.NewLinkModifierRenderer(opts...)
+		if d.HTML.Enabled {
+			rnd = markdown.NewHTMLRenderer(opts...)
 		}
-		rnd := markdown.NewLinkModifierRenderer(markdown.WithLinkResolver(lrt.resolveLink))
 		if err := rnd.Render(b, cnt.docCnt, cnt.docAst); err != nil {
 			return err
 		}
@@ -143,31 +222,272 @@ func (d *Worker) process(ctx context.Context, b *bytes.Buffer, n *manifest.Node)
 	return nil
 }
 
-func (d *Worker) processSource(ctx context.Context, sourceType string, source string, nodePath string) (*docContent, error) {
+// validateFrontmatter checks the front matter of a node document against the node schema
+func validateFrontmatter(docFrontmatter map[string]interface{}, n *manifest.Node) error {
+	if violations := frontmatter.Validate(docFrontmatter, n.FrontmatterSchema); len(violations) > 0 {
+		return fmt.Errorf("front matter of %s/%s violates its schema: %s", n.Path, n.Name(), strings.Join(violations, ", "))
+	}
+	return nil
+}
+
+// computeTitle sets the document title from its front matter, its first H1 or the node name.
+// The first H1 promoted to title is removed from the body if configured. The HTML documents
+// always get a title
+func (d *Worker) computeTitle(doc *ast.Document, dc *docContent, n *manifest.Node) {
+	var headingTitle string
+	h1 := markdown.FirstH1(doc)
+	_, hasTitle := doc.Meta()["title"]
+	if d.Hugo.TitleFromH1 && h1 != nil && !hasTitle {
+		headingTitle = markdown.HeadingText(h1, dc.docCnt)
+	}
+	frontmatter.ComputeNodeTitle(doc, n, headingTitle, d.Hugo.IndexFileNames, d.Hugo.Enabled)
+	if _, ok := doc.Meta()["title"]; d.HTML.Enabled && !ok {
+		// the HTML site navigation lists the documents by their title
+		title := frontmatter.NormalizeTitle(n.Name())
+		if h1 != nil {
+			title = markdown.HeadingText(h1, dc.docCnt)
+		}
+		fm := doc.Meta()
+		if fm == nil {
+			fm = map[string]interface{}{}
+		}
+		fm["title"] = title
+		doc.SetMeta(fm)
+	}
+	if headingTitle != "" && d.Hugo.Enabled && d.Hugo.RemoveTitleH1 {
+		doc.RemoveChild(doc, h1)
+		if dc.title == h1 {
+			dc.title = nil
+		}
+	}
+}
+
+func (d *Worker) processSource(ctx context.Context, sourceType string, source string, nodePath string, options manifest.SourceOptions) (*docContent, error) {
 	var dc *docContent
-	repoHost, err := d.Repositoryhosts.Get(source)
+	// links in a fragment are resolved relative to the original file
+	docURI, fragment := markdown.SplitFragment(source)
+	repoHost, err := d.Repositoryhosts.Get(docURI)
 	if err != nil {
 		return nil, err
 	}
-	content, err := repoHost.Read(ctx, source)
+	content, err := repoHost.Read(ctx, docURI)
 	if err != nil {
 		return nil, fmt.Errorf("reading %s %s from node %s failed: %w", sourceType, source, nodePath, err)
 	}
-	dc = &docContent{docCnt: content, docURI: source}
+	if content, err = expandIncludes(ctx, repoHost, content, docURI, nil); err != nil {
+		return nil, fmt.Errorf("fail to include documents in %s %s from node %s: %w", sourceType, source, nodePath, err)
+	}
+	dc = &docContent{docCnt: content, docURI: docURI}
 	dc.docAst, err = markdown.Parse(content)
 	if err != nil {
 		return nil, fmt.Errorf("fail to parse %s %s from node %s: %w", sourceType, source, nodePath, err)
 	}
+	if fragment != "" {
+		if err = markdown.SelectFragment(dc.docAst, content, fragment); err != nil {
+			return nil, fmt.Errorf("fail to select fragment of %s %s from node %s: %w", sourceType, source, nodePath, err)
+		}
+	}
+	normalizeHeadings(dc, options)
+	return dc, nil
+}
+
+// normalizeHeadings applies the source options to the headings of the source
+func normalizeHeadings(dc *docContent, options manifest.SourceOptions) {
+	dc.headings = markdown.Headings(dc.docAst, dc.docCnt, map[string]int{})
+	dc.headingOffset = options.DemoteHeadings
+	var title string
+	if options.InsertTitle {
+		title = sourceTitle(dc)
+	}
+	if h := markdown.FirstH1(dc.docAst); h != nil && options.DropFirstH1 {
+		for _, heading := range dc.headings {
+			if heading.Node == h {
+				dc.droppedID = heading.ID
+			}
+		}
+		dc.docAst.RemoveChild(dc.docAst, h)
+	}
+	if options.InsertTitle {
+		dc.title = markdown.InsertHeading(dc.docAst, 1, title)
+	}
+}
+
+// sourceTitle returns the source title from its front matter, its first H1 or its file name
+func sourceTitle(dc *docContent) string {
+	if doc, ok := dc.docAst.(*ast.Document); ok {
+		if title, ok := doc.Meta()["title"]; ok {
+			return fmt.Sprint(title)
+		}
+	}
+	if h := markdown.FirstH1(dc.docAst); h != nil {
+		return markdown.HeadingText(h, dc.docCnt)
+	}
+	return frontmatter.NormalizeTitle(path.Base(dc.docURI))
+}
+
+// rewriteAnchors maps the heading anchors of each source to the anchors of the headings in the
+// concatenated document, so links to fragments of the same document keep working. The anchors
+// are set as the heading ids
+func rewriteAnchors(fullContent []*docContent) {
+	ids := map[string]int{}
+	for _, dc := range fullContent {
+		final := map[*ast.Heading]string{}
+		for _, heading := range markdown.Headings(dc.docAst, dc.docCnt, ids) {
+			final[heading.Node] = heading.ID
+			heading.Node.SetAttributeString("id", []byte(heading.ID))
+		}
+		for _, heading := range dc.headings {
+			if id, ok := final[heading.Node]; ok && id != heading.ID {
+				dc.setAnchor(heading.ID, id)
+			}
+		}
+		if dc.droppedID != "" && dc.title != nil {
+			dc.setAnchor(dc.droppedID, final[dc.title])
+		}
+	}
+}
+
+func (dc *docContent) setAnchor(from string, to string) {
+	if dc.anchors == nil {
+		dc.anchors = map[string]string{}
+	}
+	dc.anchors[from] = to
+}
+
+// relativeLinks returns true if the documents link each other with relative links to their files
+func (d *Worker) relativeLinks() bool {
+	return d.MkDocs.Enabled || d.Docusaurus.Enabled || d.HTML.Enabled
+}
+
+// nodeURL returns the URL of a node document, or its path when the documents link each other with relative links
+func (d *Worker) nodeURL(n *manifest.Node) string {
+	if d.HTML.Enabled {
+		return linkresolver.HTMLPath(path.Clean(n.NodePath()))
+	}
+	if d.relativeLinks() {
+		return path.Clean(n.NodePath())
+	}
+	return linkresolver.NodeURL(n, d.Hugo)
+}
+
+// indexDocument adds the title, the tags front matter and the text of the document to the search index
+func (d *Worker) indexDocument(n *manifest.Node, docFrontmatter map[string]interface{}, fullContent []*docContent) {
+	title := strings.TrimSuffix(n.Name(), ".md")
+	if t, ok := docFrontmatter["title"]; ok && t != nil {
+		title = fmt.Sprint(t)
+	} else if h1 := markdown.FirstH1(fullContent[0].docAst); h1 != nil {
+		title = markdown.HeadingText(h1, fullContent[0].docCnt)
+	}
+	var tags []string
+	switch t := docFrontmatter["tags"].(type) {
+	case []interface{}:
+		for _, tag := range t {
+			tags = append(tags, fmt.Sprint(tag))
+		}
+	case string:
+		tags = []string{t}
+	}
+	sources := make([]searchindex.Source, 0, len(fullContent))
+	for _, cnt := range fullContent {
+		sources = append(sources, searchindex.Source{Doc: cnt.docAst, Content: cnt.docCnt})
+	}
+	d.search.Add(d.nodeURL(n), title, tags, sources)
+}
+
+// collectAnchors records the anchors of the document and its fragment links for validation
+func (d *Worker) collectAnchors(n *manifest.Node, fullContent []*docContent) {
+	target := d.nodeURL(n)
+	githubIDs, hugoIDs := map[string]int{}, map[string]int{}
+	for _, cnt := range fullContent {
+		d.anchors.AddAnchors(target, markdown.Anchors(cnt.docAst, cnt.docCnt, githubIDs, hugoIDs))
+		for _, l := range markdown.Links(cnt.docAst, cnt.docCnt) {
+			dest := l.Destination
+			if !strings.Contains(dest, "#") {
+				continue
+			}
+			if id, ok := cnt.anchors[strings.TrimPrefix(dest, "#")]; ok && strings.HasPrefix(dest, "#") {
+				dest = "#" + id
+			}
+			resolved, _, err := d.linkresolver.ResolveLink(dest, n, cnt.docURI)
+			if err != nil {
+				continue
+			}
+			base, fragment, _ := strings.Cut(resolved, "#")
+			if fragment == "" {
+				continue
+			}
+			if base == "" {
+				base = target
+			} else if d.relativeLinks() && !strings.Contains(base, "://") {
+				// links to documents are relative
+				base = path.Join(path.Dir(target), base)
+			}
+			d.anchors.AddLink(anchorvalidator.Link{Source: cnt.docURI, Line: l.Line, Target: base, Fragment: fragment})
+		}
+	}
+}
+
+// inlineTemplateData is the data of inline content templates
+type inlineTemplateData struct {
+	// Node is the node of the rendered file
+	Node *manifest.Node
+	// Children are the other nodes of the file folder
+	Children []*manifest.Node
+	// Frontmatter is the node front matter
+	Frontmatter map[string]interface{}
+}
+
+// processInline parses the content defined in the manifest. Its links are resolved relative to the manifest
+func (d *Worker) processInline(n *manifest.Node) (*docContent, error) {
+	content := []byte(n.Content)
+	if n.Template != "" {
+		tmpl, err := template.New(n.NodePath()).Parse(n.Template)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template of node %s: %w", n.NodePath(), err)
+		}
+		data := inlineTemplateData{Node: n, Frontmatter: n.Frontmatter}
+		if n.Parent() != nil {
+			for _, child := range n.Parent().Structure {
+				if child != n {
+					data.Children = append(data.Children, child)
+				}
+			}
+		}
+		var b bytes.Buffer
+		if err = tmpl.Execute(&b, data); err != nil {
+			return nil, fmt.Errorf("fail to execute template of node %s: %w", n.NodePath(), err)
+		}
+		content = b.Bytes()
+	}
+	dc := &docContent{docCnt: content, docURI: n.ManifestURL()}
+	var err error
+	if dc.docAst, err = markdown.Parse(content); err != nil {
+		return nil, fmt.Errorf("fail to parse content of node %s: %w", n.NodePath(), err)
+	}
 	return dc, nil
 }
 
 type linkResolverTask struct {
 	Worker
-	Node   *manifest.Node
-	Source string
+	Node    *manifest.Node
+	Source  string
+	Anchors map[string]string
 }
 
 func (d *linkResolverTask) resolveLink(dest string, isEmbeddable bool) (string, error) {
+	return d.resolve(dest, isEmbeddable, d.linkresolver.ResolveLink)
+}
+
+// resolveContentLink resolves the links to documents to their content paths, as the Hugo `ref` and `relref` shortcodes expect
+func (d *linkResolverTask) resolveContentLink(dest string, isEmbeddable bool) (string, error) {
+	return d.resolve(dest, isEmbeddable, d.linkresolver.ResolveContentLink)
+}
+
+// resolve resolves a link with the resolveNodeLink of the link resolver and downloads the embeddable resources
+func (d *linkResolverTask) resolve(dest string, isEmbeddable bool, resolveNodeLink func(string, *manifest.Node, string) (string, bool, error)) (string, error) {
+	if id, ok := d.Anchors[strings.TrimPrefix(dest, "#")]; ok && strings.HasPrefix(dest, "#") {
+		dest = "#" + id
+	}
 	u, err := url.Parse(dest)
 	if err != nil {
 		return dest, err
@@ -179,7 +499,7 @@ func (d *linkResolverTask) resolveLink(dest string, isEmbeddable bool) (string,
 	if err != nil {
 		return dest, err
 	}
-	newLink, shouldValidate, err := d.linkresolver.ResolveLink(dest, d.Node, d.Source)
+	newLink, shouldValidate, err := resolveNodeLink(dest, d.Node, d.Source)
 	if err != nil {
 		return dest, err
 	}
@@ -195,6 +515,14 @@ func (d *linkResolverTask) resolveLink(dest string, isEmbeddable bool) (string,
 		if err = d.downloader.Schedule(newLink, downloadResourceName, d.Source); err != nil {
 			return dest, err
 		}
+		if d.relativeLinks() {
+			// MkDocs, Docusaurus and HTML sites resolve the links to resources relative to the document
+			rel, err := filepath.Rel(path.Clean("/"+d.Node.Path), path.Join("/", d.resourcesRoot, downloadResourceName))
+			if err != nil {
+				return dest, err
+			}
+			return filepath.ToSlash(rel), nil
+		}
 		return "/" + path.Join(d.Hugo.BaseURL, d.resourcesRoot, downloadResourceName), nil
 	}
 	// convert them to raw format
@@ -5,6 +5,7 @@
 package document_test
 
 import (
+	"bytes"
 	"context"
 	"embed"
 	"fmt"
@@ -18,13 +19,18 @@ import (
 	"github.com/gardener/docforge/pkg/manifest"
 	"github.com/gardener/docforge/pkg/readers/repositoryhosts"
 	"github.com/gardener/docforge/pkg/readers/repositoryhosts/repositoryhostsfakes"
+	"github.com/gardener/docforge/pkg/workers/anchorvalidator"
 	"github.com/gardener/docforge/pkg/workers/document"
+	"github.com/gardener/docforge/pkg/workers/document/markdown"
+	"github.com/gardener/docforge/pkg/workers/document/transformer"
 	"github.com/gardener/docforge/pkg/workers/downloader/downloaderfakes"
 	"github.com/gardener/docforge/pkg/workers/linkresolver/linkresolverfakes"
 	"github.com/gardener/docforge/pkg/workers/linkvalidator/linkvalidatorfakes"
+	"github.com/gardener/docforge/pkg/workers/searchindex"
 	"github.com/gardener/docforge/pkg/writers/writersfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/yuin/goldmark/ast"
 )
 
 func TestJobs(t *testing.T) {
@@ -39,11 +45,16 @@ var _ = Describe("Document resolving", func() {
 	var (
 		dw *document.Worker
 
-		w *writersfakes.FakeWriter
+		w        *writersfakes.FakeWriter
+		df       *downloaderfakes.FakeInterface
+		lrf      *linkresolverfakes.FakeInterface
+		anchors  *anchorvalidator.Validator
+		registry *repositoryhostsfakes.FakeRegistry
 	)
 	BeforeEach(func() {
 		localHost := repositoryhostsfakes.FakeRepositoryHost{}
 		localHost.ManifestFromURLCalls(func(url string) (string, error) {
+			url = strings.Replace(url, "https://github.com/fake_owner/fake_repo/blob/master/", "tests/", 1)
 			content, err := manifests.ReadFile(url)
 			return string(content), err
 		})
@@ -60,7 +71,7 @@ var _ = Describe("Document resolving", func() {
 			return nil, nil
 		})
 		localHost.GetRawFormatLinkReturns("https://github.com/kubernetes/kubernetes/raw/master/logo/logo.png", nil)
-		registry := &repositoryhostsfakes.FakeRegistry{}
+		registry = &repositoryhostsfakes.FakeRegistry{}
 		registry.GetCalls(func(s string) (repositoryhosts.RepositoryHost, error) {
 			if strings.HasPrefix(s, "https://github.com") || s == "tests/baseline.yaml" {
 				return &localHost, nil
@@ -72,14 +83,15 @@ var _ = Describe("Document resolving", func() {
 			BaseURL:        "baseURL",
 			IndexFileNames: []string{"readme.md", "readme", "read.me", "index.md", "index"},
 		}
-		df := &downloaderfakes.FakeInterface{}
+		df = &downloaderfakes.FakeInterface{}
 		vf := &linkvalidatorfakes.FakeInterface{}
-		lrf := &linkresolverfakes.FakeInterface{}
+		lrf = &linkresolverfakes.FakeInterface{}
 		lrf.ResolveLinkCalls(func(s1 string, n *manifest.Node, s2 string) (string, bool, error) {
 			return s1, true, nil
 		})
 		w = &writersfakes.FakeWriter{}
-		dw = document.NewDocumentWorker("__resources", df, vf, lrf, registry, hugo, w)
+		anchors = anchorvalidator.New()
+		dw = document.NewDocumentWorker("__resources", df, vf, anchors, nil, lrf, registry, document.Options{Hugo: hugo}, w)
 	})
 
 	Context("#ProcessNode", func() {
@@ -127,5 +139,414 @@ var _ = Describe("Document resolving", func() {
 			Expect(node).To(Equal(nodegot))
 		})
 
+		It("selects a fragment of the source", func() {
+			node := &manifest.Node{
+				FileType: manifest.FileType{
+					File:   "node.md",
+					Source: "https://github.com/fake_owner/fake_repo/blob/master/fragment.md#installation",
+				},
+				Type: "file",
+				Path: "one",
+			}
+			err := dw.ProcessNode(context.TODO(), node)
+			Expect(err).ToNot(HaveOccurred())
+			_, _, cnt, _ := w.WriteArgsForCall(0)
+			Expect(string(cnt)).To(Equal("---\ntitle: Node\n---\n\n## Installation\n\nSee [setup](./setup.md).\n\n### Details\n\nMore.\n"))
+			Expect(lrf.ResolveLinkCallCount()).To(Equal(1))
+			_, _, source := lrf.ResolveLinkArgsForCall(0)
+			Expect(source).To(Equal("https://github.com/fake_owner/fake_repo/blob/master/fragment.md"))
+		})
+
+		It("renders inline content templates", func() {
+			nodes, err := manifest.ResolveManifest("https://github.com/fake_owner/fake_repo/blob/master/inline.yaml", registry)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nodes[2].Name()).To(Equal("_index.md"))
+			Expect(dw.ProcessNode(context.TODO(), nodes[2])).To(Succeed())
+			_, _, cnt, _ := w.WriteArgsForCall(0)
+			Expect(string(cnt)).To(Equal("---\ntitle: Docs\n---\n\n# Docs\n\n- [target.md](https://github.com/fake_owner/fake_repo/blob/master/target.md)\n"))
+		})
+
+		It("resolves inline content links relative to the manifest", func() {
+			nodes, err := manifest.ResolveManifest("https://github.com/fake_owner/fake_repo/blob/master/inline.yaml", registry)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nodes[3].Name()).To(Equal("glue.md"))
+			Expect(dw.ProcessNode(context.TODO(), nodes[3])).To(Succeed())
+			_, _, cnt, _ := w.WriteArgsForCall(0)
+			Expect(string(cnt)).To(ContainSubstring("See [target](./target.md).\n"))
+			dest, _, source := lrf.ResolveLinkArgsForCall(0)
+			Expect(dest).To(Equal("./target.md"))
+			Expect(source).To(Equal("https://github.com/fake_owner/fake_repo/blob/master/inline.yaml"))
+		})
+
+		It("runs the transformers before rendering", func() {
+			node := &manifest.Node{
+				FileType: manifest.FileType{
+					File:   "node.md",
+					Source: "https://github.com/fake_owner/fake_repo/blob/master/fragment.md#installation",
+				},
+				Transformers: map[string]interface{}{"drop-details": map[string]interface{}{"heading": "Details"}},
+				Type:         "file",
+				Path:         "one",
+			}
+			dw.Transformers = transformer.NewRegistry()
+			Expect(dw.Transformers.Register("drop-details", transformer.Func(func(doc ast.Node, ctx *transformer.Context) error {
+				Expect(ctx.Node).To(Equal(node))
+				Expect(ctx.SourceURI).To(Equal("https://github.com/fake_owner/fake_repo/blob/master/fragment.md"))
+				Expect(ctx.Frontmatter).To(HaveKeyWithValue("title", "Node"))
+				for c := doc.FirstChild(); c != nil; c = c.NextSibling() {
+					if h, ok := c.(*ast.Heading); ok && string(h.Text(ctx.Source)) == ctx.Options["heading"] {
+						doc.RemoveChild(doc, c.NextSibling())
+						doc.RemoveChild(doc, c)
+						break
+					}
+				}
+				ctx.Frontmatter["transformed"] = true
+				return nil
+			}), false)).To(Succeed())
+			Expect(dw.ProcessNode(context.TODO(), node)).To(Succeed())
+			_, _, cnt, _ := w.WriteArgsForCall(0)
+			Expect(string(cnt)).To(Equal("---\ntitle: Node\ntransformed: true\n---\n\n## Installation\n\nSee [setup](./setup.md).\n"))
+		})
+
+		It("normalizes the headings of the sources", func() {
+			a, b := "https://github.com/fake_owner/fake_repo/blob/master/component_a.md", "https://github.com/fake_owner/fake_repo/blob/master/component_b.md"
+			node := &manifest.Node{
+				FileType: manifest.FileType{
+					File:        "components.md",
+					MultiSource: []string{a, b},
+					SourceOptions: map[string]manifest.SourceOptions{
+						a: {DemoteHeadings: 1},
+						b: {DemoteHeadings: 1, DropFirstH1: true, InsertTitle: true},
+					},
+				},
+				Type: "file",
+				Path: "one",
+			}
+			Expect(dw.ProcessNode(context.TODO(), node)).To(Succeed())
+			_, _, cnt, _ := w.WriteArgsForCall(0)
+			Expect(string(cnt)).To(Equal("---\ntitle: Component B\n---\n\n## Component A\n\nSee [installation](#installation).\n\n### Installation\n\nInstall A.\n" +
+				"## Component B\n\nRead the [installation](#installation-1) and the [overview](#component-b).\n\n### Installation\n\nInstall B.\n\n### More details\n\nDetails.\n"))
+		})
+
+		It("uses the first H1 as title", func() {
+			dw.Hugo.TitleFromH1 = true
+			dw.Hugo.RemoveTitleH1 = true
+			node := &manifest.Node{
+				FileType: manifest.FileType{
+					File:   "deploy_gardenlet.md",
+					Source: "https://github.com/fake_owner/fake_repo/blob/master/h1_title.md",
+				},
+				Type: "file",
+				Path: "one",
+			}
+			Expect(dw.ProcessNode(context.TODO(), node)).To(Succeed())
+			_, _, cnt, _ := w.WriteArgsForCall(0)
+			Expect(string(cnt)).To(Equal("---\ntitle: Deploying the Gardenlet\n---\n\nIntro.\n\n## Steps\n\nDeploy it.\n"))
+		})
+
+		It("resolves the links in shortcodes", func() {
+			var err error
+			dw.ShortcodeParams, err = markdown.ParseShortcodeParams(markdown.DefaultShortcodeLinks)
+			Expect(err).NotTo(HaveOccurred())
+			lrf.ResolveContentLinkCalls(func(s1 string, n *manifest.Node, s2 string) (string, bool, error) {
+				return strings.Replace(s1, "../concepts/apiserver.md", "/concepts/apiserver.md", 1), true, nil
+			})
+			node := &manifest.Node{
+				FileType: manifest.FileType{
+					File:   "shortcodes.md",
+					Source: "https://github.com/fake_owner/fake_repo/blob/master/shortcodes.md",
+				},
+				Type: "file",
+				Path: "one",
+			}
+			Expect(dw.ProcessNode(context.TODO(), node)).To(Succeed())
+			_, _, cnt, _ := w.WriteArgsForCall(0)
+			Expect(string(cnt)).To(Equal("---\ntitle: Shortcodes\n---\n\n# Shortcodes\n\n" +
+				"Read the {{< ref \"/concepts/apiserver.md\" >}} reference.\n\n" +
+				"{{< figure src=\"/baseURL/__resources/architecture_53221f.png\" caption=\"Architecture\" >}}\n"))
+		})
+
+		It("reports the fragment links to missing anchors", func() {
+			node := &manifest.Node{
+				FileType: manifest.FileType{
+					File:   "anchors.md",
+					Source: "https://github.com/fake_owner/fake_repo/blob/master/anchors.md",
+				},
+				Type: "file",
+				Path: "one",
+			}
+			Expect(dw.ProcessNode(context.TODO(), node)).To(Succeed())
+			Expect(anchors.Validate()).To(Equal([]string{
+				"broken anchor #missing in https://github.com/fake_owner/fake_repo/blob/master/anchors.md:12: /baseURL/one/anchors/ has no such anchor",
+			}))
+		})
+
+		It("includes documents", func() {
+			node := &manifest.Node{
+				FileType: manifest.FileType{
+					File:   "guide.md",
+					Source: "https://github.com/fake_owner/fake_repo/blob/master/including.md",
+				},
+				Type: "file",
+				Path: "one",
+			}
+			Expect(dw.ProcessNode(context.TODO(), node)).To(Succeed())
+			_, _, cnt, _ := w.WriteArgsForCall(0)
+			Expect(string(cnt)).To(Equal("---\ntitle: Guide\n---\n\n# Guide\n\n## Prerequisites\n\n" +
+				"Install [the tool](tool.md) and read the [details](#details).\n\n" +
+				"### Details\n\n![diagram](/baseURL/__resources/diagram_dd3ea2.png)\n\n" +
+				"## Usage\n\nUse it as shown in the `<!-- docforge:include ./usage.md -->` example.\n"))
+		})
+
+		It("resolves the links of nested includes in other folders", func() {
+			node := &manifest.Node{
+				FileType: manifest.FileType{
+					File:   "nested.md",
+					Source: "https://github.com/fake_owner/fake_repo/blob/master/nested/guide.md",
+				},
+				Type: "file",
+				Path: "one",
+			}
+			Expect(dw.ProcessNode(context.TODO(), node)).To(Succeed())
+			_, _, cnt, _ := w.WriteArgsForCall(0)
+			Expect(string(cnt)).To(ContainSubstring("Install [the tool](../tool.md) and read the [details](#details).\n"))
+			Expect(df.ScheduleCallCount()).To(Equal(1))
+			source, _, document := df.ScheduleArgsForCall(0)
+			Expect(source).To(Equal("../snippets/diagram.png"))
+			Expect(document).To(Equal("https://github.com/fake_owner/fake_repo/blob/master/nested/guide.md"))
+		})
+
+		It("links the resources relative to the document with MkDocs", func() {
+			dw.Hugo.Enabled = false
+			dw.MkDocs.Enabled = true
+			node := &manifest.Node{
+				FileType: manifest.FileType{
+					File:   "guide.md",
+					Source: "https://github.com/fake_owner/fake_repo/blob/master/including.md",
+				},
+				Type: "file",
+				Path: "one/two",
+			}
+			Expect(dw.ProcessNode(context.TODO(), node)).To(Succeed())
+			_, _, cnt, _ := w.WriteArgsForCall(0)
+			Expect(string(cnt)).To(ContainSubstring("![diagram](../../__resources/diagram_dd3ea2.png)"))
+		})
+
+		It("renders MDX-safe content with Docusaurus", func() {
+			dw.Hugo.Enabled = false
+			dw.Docusaurus.Enabled = true
+			node := &manifest.Node{
+				FileType: manifest.FileType{
+					File:   "mdx.md",
+					Source: "https://github.com/fake_owner/fake_repo/blob/master/mdx.md",
+				},
+				Type: "file",
+				Path: "one",
+			}
+			Expect(dw.ProcessNode(context.TODO(), node)).To(Succeed())
+			_, _, cnt, _ := w.WriteArgsForCall(0)
+			Expect(string(cnt)).To(Equal("---\nid: mdx\n---\n\n# MDX\n\nUse it when a \\< b with \\{name}.<br />\n\n{/* internal note */}\n"))
+		})
+
+		It("renders the documents as HTML with their heading anchors for the HTML site", func() {
+			dw.Hugo.Enabled = false
+			dw.HTML.Enabled = true
+			node := &manifest.Node{
+				FileType: manifest.FileType{
+					File:        "components.md",
+					MultiSource: []string{"https://github.com/fake_owner/fake_repo/blob/master/component_a.md", "https://github.com/fake_owner/fake_repo/blob/master/component_b.md"},
+				},
+				Type: "file",
+				Path: "one",
+			}
+			Expect(dw.ProcessNode(context.TODO(), node)).To(Succeed())
+			_, _, cnt, _ := w.WriteArgsForCall(0)
+			Expect(string(cnt)).To(Equal("---\ntitle: Component B\n---\n<h1 id=\"component-a\">Component A</h1>\n<p>See <a href=\"#installation\">installation</a>.</p>\n<h2 id=\"installation\">Installation</h2>\n<p>Install A.</p>\n" +
+				"<h1 id=\"component-b\">Component B</h1>\n<p>Read the <a href=\"#installation-1\">installation</a> and the <a href=\"#component-b\">overview</a>.</p>\n<h2 id=\"installation-1\">Installation</h2>\n<p>Install B.</p>\n<h2 id=\"more-details\">More\ndetails</h2>\n<p>Details.</p>\n"))
+		})
+
+		It("indexes the document text for search", func() {
+			search, err := searchindex.New(searchindex.DefaultFields, []string{"changelog"})
+			Expect(err).NotTo(HaveOccurred())
+			dw = document.NewDocumentWorker("__resources", &downloaderfakes.FakeInterface{}, &linkvalidatorfakes.FakeInterface{}, nil, search, lrf, registry, dw.Options, w)
+			node := &manifest.Node{
+				FileType: manifest.FileType{
+					File:   "search.md",
+					Source: "https://github.com/fake_owner/fake_repo/blob/master/search.md",
+				},
+				Type: "file",
+				Path: "one",
+			}
+			Expect(dw.ProcessNode(context.TODO(), node)).To(Succeed())
+			var b bytes.Buffer
+			Expect(search.Write(&b, searchindex.Lunr)).To(Succeed())
+			Expect(b.String()).To(Equal("[\n  {\n    \"url\": \"/baseURL/one/search/\",\n    \"title\": \"Search\",\n    \"headings\": [\n      \"Search\"\n    ],\n    \"body\": \"Find the documents.\",\n    \"tags\": [\n      \"guide\"\n    ]\n  }\n]\n"))
+		})
+
+		It("fails for an include cycle", func() {
+			node := &manifest.Node{
+				FileType: manifest.FileType{
+					File:   "node.md",
+					Source: "https://github.com/fake_owner/fake_repo/blob/master/include_cycle_a.md",
+				},
+				Type: "file",
+				Path: "one",
+			}
+			err := dw.ProcessNode(context.TODO(), node)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("include cycle https://github.com/fake_owner/fake_repo/blob/master/include_cycle_a.md -> " +
+				"https://github.com/fake_owner/fake_repo/blob/master/include_cycle_b.md -> https://github.com/fake_owner/fake_repo/blob/master/include_cycle_a.md"))
+		})
+
+		It("embeds code snippets", func() {
+			node := &manifest.Node{
+				FileType: manifest.FileType{
+					File:   "snippets.md",
+					Source: "https://github.com/fake_owner/fake_repo/blob/master/snippets.md",
+				},
+				Type: "file",
+				Path: "one",
+			}
+			Expect(dw.ProcessNode(context.TODO(), node)).To(Succeed())
+			_, _, cnt, _ := w.WriteArgsForCall(0)
+			Expect(string(cnt)).To(Equal("---\ntitle: Snippets\n---\n\n# Snippets\n\n```yaml\nmetadata:\n  name: crazy-botany\n  namespace: garden-dev\n```\n\n" +
+				"- Provider:\n  \n  ```yaml\n    provider:\n      type: aws\n  ```\n"))
+		})
+
+		It("fails for a missing snippet region", func() {
+			node := &manifest.Node{
+				FileType: manifest.FileType{
+					File:   "node.md",
+					Source: "https://github.com/fake_owner/fake_repo/blob/master/snippet_missing.md",
+				},
+				Type: "file",
+				Path: "one",
+			}
+			err := dw.ProcessNode(context.TODO(), node)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fail to embed snippet ./example/shoot.yaml in https://github.com/fake_owner/fake_repo/blob/master/snippet_missing.md: region missing not found"))
+		})
+
+		It("fails for front matter violating the schema", func() {
+			node := &manifest.Node{
+				FileType: manifest.FileType{
+					File:   "node.md",
+					Source: "https://github.com/fake_owner/fake_repo/blob/master/target.md",
+				},
+				Frontmatter: map[string]interface{}{"tags": "one"},
+				FrontmatterSchema: map[string]manifest.FrontmatterKey{
+					"description": {Type: "string", Required: true},
+					"tags":        {Type: "list"},
+				},
+				Type: "file",
+				Path: "one",
+			}
+			err := dw.ProcessNode(context.TODO(), node)
+			Expect(err).To(MatchError("front matter of one/node.md violates its schema: missing required key description, key tags must be a list, got string"))
+			Expect(w.WriteCallCount()).To(Equal(0))
+		})
+
+		It("fails for front matter violating the schema of nodes without content", func() {
+			node := &manifest.Node{
+				FileType:    manifest.FileType{File: "_index.md"},
+				Frontmatter: map[string]interface{}{"title": "Docs"},
+				FrontmatterSchema: map[string]manifest.FrontmatterKey{
+					"description": {Type: "string", Required: true},
+				},
+				Type: "file",
+				Path: "one",
+			}
+			err := dw.ProcessNode(context.TODO(), node)
+			Expect(err).To(MatchError("front matter of one/_index.md violates its schema: missing required key description"))
+			Expect(w.WriteCallCount()).To(Equal(0))
+		})
+
+		It("validates the front matter set by the transformers", func() {
+			node := &manifest.Node{
+				FileType: manifest.FileType{
+					File:   "node.md",
+					Source: "https://github.com/fake_owner/fake_repo/blob/master/target.md",
+				},
+				FrontmatterSchema: map[string]manifest.FrontmatterKey{
+					"description": {Type: "string", Required: true},
+				},
+				Transformers: map[string]interface{}{"describe": true},
+				Type:         "file",
+				Path:         "one",
+			}
+			dw.Transformers = transformer.NewRegistry()
+			Expect(dw.Transformers.Register("describe", transformer.Func(func(_ ast.Node, ctx *transformer.Context) error {
+				ctx.Frontmatter["description"] = "Described"
+				return nil
+			}), false)).To(Succeed())
+			Expect(dw.ProcessNode(context.TODO(), node)).To(Succeed())
+			_, _, cnt, _ := w.WriteArgsForCall(0)
+			Expect(string(cnt)).To(ContainSubstring("description: Described\n"))
+		})
+
+		It("generates the table of contents after the first heading", func() {
+			a, b := "https://github.com/fake_owner/fake_repo/blob/master/component_a.md", "https://github.com/fake_owner/fake_repo/blob/master/component_b.md"
+			node := &manifest.Node{
+				FileType: manifest.FileType{
+					File:        "components.md",
+					MultiSource: []string{a, b},
+				},
+				Transformers: map[string]interface{}{"toc": map[string]interface{}{"minLevel": 1, "maxLevel": 2}},
+				Type:         "file",
+				Path:         "one",
+			}
+			Expect(dw.ProcessNode(context.TODO(), node)).To(Succeed())
+			_, _, cnt, _ := w.WriteArgsForCall(0)
+			Expect(string(cnt)).To(HavePrefix("---\ntitle: Component B\n---\n\n# Component A\n\n" +
+				"- [Component A](#component-a)\n  - [Installation](#installation)\n- [Component B](#component-b)\n  - [Installation](#installation-1)\n  - [More details](#more-details)\n\n" +
+				"See [installation](#installation).\n"))
+		})
+
+		It("generates the table of contents at its marker", func() {
+			node := &manifest.Node{
+				FileType: manifest.FileType{
+					File:   "toc.md",
+					Source: "https://github.com/fake_owner/fake_repo/blob/master/toc.md",
+				},
+				Transformers: map[string]interface{}{"toc": true},
+				Type:         "file",
+				Path:         "one",
+			}
+			Expect(dw.ProcessNode(context.TODO(), node)).To(Succeed())
+			_, _, cnt, _ := w.WriteArgsForCall(0)
+			Expect(string(cnt)).To(Equal("---\ntitle: Toc\n---\n\n# Setup\n\nIntro.\n\n" +
+				"- [Install \\[stable\\]](#install-stable)\n  - [Linux](#linux)\n- [Configure](#configure)\n\n" +
+				"## Install [stable]\n\n### Linux\n\n#### Deep\n\n## Configure\n"))
+		})
+
+		It("generates the table of contents after a first heading of a lower level", func() {
+			node := &manifest.Node{
+				FileType: manifest.FileType{
+					File:   "installation.md",
+					Source: "https://github.com/fake_owner/fake_repo/blob/master/fragment.md#installation",
+				},
+				Transformers: map[string]interface{}{"toc": true},
+				Type:         "file",
+				Path:         "one",
+			}
+			Expect(dw.ProcessNode(context.TODO(), node)).To(Succeed())
+			_, _, cnt, _ := w.WriteArgsForCall(0)
+			Expect(string(cnt)).To(HavePrefix("---\ntitle: Installation\n---\n\n## Installation\n\n" +
+				"- [Installation](#installation)\n  - [Details](#details)\n\nSee [setup]"))
+		})
+
+		It("fails for a missing fragment", func() {
+			node := &manifest.Node{
+				FileType: manifest.FileType{
+					File:   "node.md",
+					Source: "https://github.com/fake_owner/fake_repo/blob/master/fragment.md#missing",
+				},
+				Type: "file",
+				Path: "one",
+			}
+			err := dw.ProcessNode(context.TODO(), node)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("heading #missing not found"))
+		})
 	})
 })
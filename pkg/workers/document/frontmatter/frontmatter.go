@@ -6,8 +6,10 @@ package frontmatter
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/gardener/docforge/pkg/frontmatter"
 	"github.com/gardener/docforge/pkg/manifest"
 )
 
@@ -21,6 +23,15 @@ type NodeMeta interface {
 	SetMeta(map[string]interface{})
 }
 
+// Parse returns the front matter of a written document or nil if it has none
+func Parse(docBlob []byte) map[string]interface{} {
+	fm, _, err := frontmatter.Split(docBlob)
+	if err != nil {
+		return nil
+	}
+	return fm
+}
+
 // MoveMultiSourceFrontmatterToTopDocument moves MultiSource frontmatter to top document
 func MoveMultiSourceFrontmatterToTopDocument(dc []NodeMeta) {
 	if len(dc) < 2 {
@@ -61,8 +72,9 @@ func MergeDocumentAndNodeFrontmatter(nodeAst NodeMeta, node *manifest.Node) {
 // ComputeNodeTitle Determines node title from its name or its parent name if
 // it is eligible to be index file, and then normalizes either
 // as a title - removing `-`, `_`, `.md` and converting to title
-// case.
-func ComputeNodeTitle(nodeAst NodeMeta, node *manifest.Node, IndexFileNames []string, hugoEnabled bool) {
+// case. A title in the document front matter is kept and a non-empty
+// headingTitle, the text of the document first H1, takes precedence over the name.
+func ComputeNodeTitle(nodeAst NodeMeta, node *manifest.Node, headingTitle string, IndexFileNames []string, hugoEnabled bool) {
 	if !hugoEnabled || nodeAst == nil {
 		return
 	}
@@ -78,16 +90,88 @@ func ComputeNodeTitle(nodeAst NodeMeta, node *manifest.Node, IndexFileNames []st
 		// root index node
 		title = "Root"
 	}
-	title = strings.TrimSuffix(title, ".md")
-	title = strings.ReplaceAll(title, "_", " ")
-	title = strings.ReplaceAll(title, "-", " ")
-	title = strings.Title(title)
 	if _, ok := docFrontmatter["title"]; !ok {
-		docFrontmatter["title"] = title
+		if headingTitle != "" {
+			docFrontmatter["title"] = headingTitle
+		} else {
+			docFrontmatter["title"] = NormalizeTitle(title)
+		}
 	}
 	nodeAst.SetMeta(docFrontmatter)
 }
 
+// SetDocusaurusMeta sets the Docusaurus `id` and `sidebar_position` front matter of a document, unless
+// already set. The id is the node name without extension and the position is the 1-based position of
+// the node in its parent
+func SetDocusaurusMeta(nodeAst NodeMeta, node *manifest.Node) {
+	if nodeAst == nil || node == nil {
+		return
+	}
+	docFrontmatter := nodeAst.Meta()
+	if docFrontmatter == nil {
+		docFrontmatter = map[string]interface{}{}
+	}
+	if _, ok := docFrontmatter["id"]; !ok {
+		docFrontmatter["id"] = strings.TrimSuffix(node.Name(), ".md")
+	}
+	if _, ok := docFrontmatter["sidebar_position"]; !ok && node.Parent() != nil {
+		for i, child := range node.Parent().Structure {
+			if child == node {
+				docFrontmatter["sidebar_position"] = i + 1
+				break
+			}
+		}
+	}
+	nodeAst.SetMeta(docFrontmatter)
+}
+
+// NormalizeTitle converts a file name to a title - removing `-`, `_`, `.md` and converting to title case
+func NormalizeTitle(name string) string {
+	title := strings.TrimSuffix(name, ".md")
+	title = strings.ReplaceAll(title, "_", " ")
+	title = strings.ReplaceAll(title, "-", " ")
+	return strings.Title(title)
+}
+
+// Validate returns the violations of the front matter schema sorted by key
+func Validate(docFrontmatter map[string]interface{}, schema map[string]manifest.FrontmatterKey) []string {
+	keys := make([]string, 0, len(schema))
+	for k := range schema {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var violations []string
+	for _, k := range keys {
+		v, ok := docFrontmatter[k]
+		if !ok {
+			if schema[k].Required {
+				violations = append(violations, fmt.Sprintf("missing required key %s", k))
+			}
+			continue
+		}
+		if t := schema[k].Type; t != "" && !hasType(v, t) {
+			violations = append(violations, fmt.Sprintf("key %s must be a %s, got %T", k, t, v))
+		}
+	}
+	return violations
+}
+
+func hasType(v interface{}, t string) bool {
+	switch v.(type) {
+	case string:
+		return t == "string"
+	case int, int64, uint64, float64:
+		return t == "number"
+	case bool:
+		return t == "boolean"
+	case []interface{}, []string:
+		return t == "list"
+	case map[string]interface{}, map[interface{}]interface{}:
+		return t == "map"
+	}
+	return false
+}
+
 // Compares a node name to the configured list of index file
 // and a default name '_index.md' to determine if this node
 // is an index document node.
@@ -17,6 +17,7 @@ import (
 	"github.com/gardener/docforge/pkg/workers/document/frontmatter/frontmatterfakes"
 
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 )
 
@@ -170,7 +171,7 @@ var _ = Describe("Document frontmatter", func() {
 		Context("top level node", func() {
 			It("removes _,- and .md in the general case", func() {
 				node = nodes[1]
-				frontmatter.ComputeNodeTitle(nodeAst, node, indexFileNames, hugoEnabled)
+				frontmatter.ComputeNodeTitle(nodeAst, node, "", indexFileNames, hugoEnabled)
 				setMeta := nodeAst.SetMetaArgsForCall(0)
 				Expect(setMeta).To(Equal(map[string]interface{}{
 					"title": "File Node 1",
@@ -178,7 +179,7 @@ var _ = Describe("Document frontmatter", func() {
 			})
 			It("has title Root if file is index", func() {
 				node = nodes[2]
-				frontmatter.ComputeNodeTitle(nodeAst, node, indexFileNames, hugoEnabled)
+				frontmatter.ComputeNodeTitle(nodeAst, node, "", indexFileNames, hugoEnabled)
 				setMeta := nodeAst.SetMetaArgsForCall(0)
 				Expect(setMeta).To(Equal(map[string]interface{}{
 					"title": "Root",
@@ -187,7 +188,7 @@ var _ = Describe("Document frontmatter", func() {
 			Context("node with parent", func() {
 				It("removes _,- and .md in the general case", func() {
 					node = nodes[4]
-					frontmatter.ComputeNodeTitle(nodeAst, node, indexFileNames, hugoEnabled)
+					frontmatter.ComputeNodeTitle(nodeAst, node, "", indexFileNames, hugoEnabled)
 					setMeta := nodeAst.SetMetaArgsForCall(0)
 					Expect(setMeta).To(Equal(map[string]interface{}{
 						"title": "File Node 2",
@@ -195,15 +196,88 @@ var _ = Describe("Document frontmatter", func() {
 				})
 				It("uses parents name if file is index", func() {
 					node = nodes[5]
-					frontmatter.ComputeNodeTitle(nodeAst, node, indexFileNames, hugoEnabled)
+					frontmatter.ComputeNodeTitle(nodeAst, node, "", indexFileNames, hugoEnabled)
 					setMeta := nodeAst.SetMetaArgsForCall(0)
 					Expect(setMeta).To(Equal(map[string]interface{}{
 						"title": "Parent Dir",
 					}))
 				})
+				It("uses the heading title over the name", func() {
+					node = nodes[5]
+					frontmatter.ComputeNodeTitle(nodeAst, node, "Deploying the Gardenlet", indexFileNames, hugoEnabled)
+					setMeta := nodeAst.SetMetaArgsForCall(0)
+					Expect(setMeta).To(Equal(map[string]interface{}{
+						"title": "Deploying the Gardenlet",
+					}))
+				})
+				It("keeps the front matter title", func() {
+					node = nodes[4]
+					nodeAst.MetaReturns(map[string]interface{}{"title": "Custom"})
+					frontmatter.ComputeNodeTitle(nodeAst, node, "Deploying the Gardenlet", indexFileNames, hugoEnabled)
+					setMeta := nodeAst.SetMetaArgsForCall(0)
+					Expect(setMeta).To(Equal(map[string]interface{}{
+						"title": "Custom",
+					}))
+				})
 			})
 
 		})
 	})
-
+	Context("#SetDocusaurusMeta", func() {
+		var (
+			nodeAst *frontmatterfakes.FakeNodeMeta
+			nodes   []*manifest.Node
+			err     error
+		)
+		BeforeEach(func() {
+			nodes, err = manifest.ResolveManifest("tests/titles.yaml", repositoryhostsfakes.FilesystemRegistry(manifests))
+			Expect(err).NotTo(HaveOccurred())
+			nodeAst = &frontmatterfakes.FakeNodeMeta{}
+		})
+		It("sets the id and the position in the parent", func() {
+			frontmatter.SetDocusaurusMeta(nodeAst, nodes[5])
+			Expect(nodeAst.SetMetaArgsForCall(0)).To(Equal(map[string]interface{}{
+				"id":               "README",
+				"sidebar_position": 2,
+			}))
+		})
+		It("keeps the front matter id and position", func() {
+			nodeAst.MetaReturns(map[string]interface{}{"id": "node", "sidebar_position": 10})
+			frontmatter.SetDocusaurusMeta(nodeAst, nodes[1])
+			Expect(nodeAst.SetMetaArgsForCall(0)).To(Equal(map[string]interface{}{
+				"id":               "node",
+				"sidebar_position": 10,
+			}))
+		})
+	})
+	Context("#Parse", func() {
+		It("returns the front matter of a document", func() {
+			Expect(frontmatter.Parse([]byte("---\ntitle: Intro\n---\n# Intro\n"))).To(Equal(map[string]interface{}{"title": "Intro"}))
+		})
+		It("returns nil for a document without front matter", func() {
+			Expect(frontmatter.Parse([]byte("# Intro\n---\n"))).To(BeNil())
+		})
+	})
+	Context("#Validate", func() {
+		schema := map[string]manifest.FrontmatterKey{
+			"description": {Type: "string", Required: true},
+			"tags":        {Type: "list"},
+			"weight":      {Type: "number"},
+			"params":      {Type: "map"},
+			"draft":       {},
+		}
+		DescribeTable("validating front matter",
+			func(fm map[string]interface{}, expected []string) {
+				Expect(frontmatter.Validate(fm, schema)).To(Equal(expected))
+			},
+			Entry("valid front matter", map[string]interface{}{
+				"description": "Guide", "tags": []interface{}{"a"}, "weight": 10, "params": map[interface{}]interface{}{"a": 1}, "draft": "yes",
+			}, nil),
+			Entry("missing required key", map[string]interface{}{"tags": []interface{}{}}, []string{"missing required key description"}),
+			Entry("wrong types", map[string]interface{}{"description": "Guide", "tags": "a,b", "weight": "10"}, []string{
+				"key tags must be a list, got string",
+				"key weight must be a number, got string",
+			}),
+		)
+	})
 })
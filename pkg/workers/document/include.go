@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package document
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/gardener/docforge/pkg/readers/repositoryhosts"
+	"github.com/gardener/docforge/pkg/workers/document/markdown"
+	"github.com/yuin/goldmark/ast"
+)
+
+// maxIncludeDepth limits the nesting of included documents
+const maxIncludeDepth = 10
+
+// expandIncludes replaces the transclusion directives of a document with the included documents.
+// Included documents are read with the repository host of the including document and their links
+// are rewritten relative to it, so nested includes keep pointing to the same resources. chain holds
+// the documents including this one. The document is parsed only when it contains a directive.
+// The line numbers reported for the expanded document, e.g. of broken anchors, are the lines of the
+// expanded content, not of the source file
+func expandIncludes(ctx context.Context, repoHost repositoryhosts.RepositoryHost, content []byte, docURI string, chain []string) ([]byte, error) {
+	if !bytes.Contains(content, []byte("docforge:include")) {
+		return content, nil
+	}
+	doc, err := markdown.Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("fail to parse %s: %w", docURI, err)
+	}
+	includes := markdown.Includes(doc, content)
+	if len(includes) == 0 {
+		return content, nil
+	}
+	chain = append(chain, docURI)
+	if len(chain) > maxIncludeDepth {
+		return nil, fmt.Errorf("includes of %s exceed the maximum depth of %d", chain[0], maxIncludeDepth)
+	}
+	var b bytes.Buffer
+	last := 0
+	for _, inc := range includes {
+		absLink, err := repoHost.ToAbsLink(docURI, inc.Link)
+		if err != nil {
+			return nil, fmt.Errorf("fail to resolve include %s in %s: %w", inc.Link, docURI, err)
+		}
+		includedURI, fragment := markdown.SplitFragment(absLink)
+		if slices.Contains(chain, includedURI) {
+			return nil, fmt.Errorf("include cycle %s -> %s", strings.Join(chain, " -> "), includedURI)
+		}
+		included, err := repoHost.Read(ctx, includedURI)
+		if err != nil {
+			return nil, fmt.Errorf("reading include %s in %s failed: %w", inc.Link, docURI, err)
+		}
+		if included, err = expandIncludes(ctx, repoHost, included, includedURI, chain); err != nil {
+			return nil, err
+		}
+		if included, err = renderIncluded(ctx, repoHost, included, includedURI, docURI, fragment, inc.HeadingOffset); err != nil {
+			return nil, err
+		}
+		b.Write(content[last:inc.Start])
+		// the included blocks must not continue a preceding paragraph
+		if inc.Start > 0 && !bytes.HasSuffix(content[:inc.Start], []byte("\n\n")) {
+			b.WriteByte('\n')
+		}
+		b.Write(included)
+		last = inc.Stop
+	}
+	b.Write(content[last:])
+	return b.Bytes(), nil
+}
+
+// renderIncluded renders an included document without its front matter. Its relative links are
+// rewritten relative to the document includerURI including it, or to absolute for other repositories,
+// so they keep pointing to the same resources
+func renderIncluded(ctx context.Context, repoHost repositoryhosts.RepositoryHost, content []byte, docURI string, includerURI string, fragment string, headingOffset int) ([]byte, error) {
+	doc, err := markdown.Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("fail to parse include %s: %w", docURI, err)
+	}
+	if fragment != "" {
+		if err = markdown.SelectFragment(doc, content, fragment); err != nil {
+			return nil, fmt.Errorf("fail to select fragment of include %s: %w", docURI, err)
+		}
+	}
+	if d, ok := doc.(*ast.Document); ok {
+		d.SetMeta(nil)
+	}
+	resolve := func(dest string, isEmbeddable bool) (string, error) {
+		u, err := url.Parse(dest)
+		if err != nil || u.IsAbs() || strings.HasPrefix(dest, "#") {
+			return dest, nil
+		}
+		absLink, err := repoHost.ToAbsLink(docURI, dest)
+		if err != nil {
+			return dest, err
+		}
+		return relativeLink(includerURI, absLink), nil
+	}
+	var b bytes.Buffer
+	snippets := func(snippet markdown.Snippet) ([]byte, error) {
+		return readSnippet(ctx, repoHost, docURI, snippet)
+	}
+	rnd := markdown.NewLinkModifierRenderer(markdown.WithLinkResolver(resolve), markdown.WithHeadingOffset(headingOffset), markdown.WithSnippetResolver(snippets))
+	if err = rnd.Render(&b, content, doc); err != nil {
+		return nil, fmt.Errorf("fail to render include %s: %w", docURI, err)
+	}
+	if b.Len() > 0 && !bytes.HasSuffix(b.Bytes(), []byte("\n")) {
+		b.WriteByte('\n')
+	}
+	return b.Bytes(), nil
+}
+
+// relativeLink returns link relative to the document docURI if both are in the same repository
+// and ref, e.g. https://github.com/owner/repo/blob/ref/path, otherwise link
+func relativeLink(docURI string, link string) string {
+	d, err := url.Parse(docURI)
+	if err != nil {
+		return link
+	}
+	l, err := url.Parse(link)
+	if err != nil || d.Scheme != l.Scheme || d.Host != l.Host {
+		return link
+	}
+	dp, lp := strings.Split(d.Path, "/"), strings.Split(l.Path, "/")
+	if len(dp) < 6 || len(lp) < 6 || dp[1] != lp[1] || dp[2] != lp[2] || dp[4] != lp[4] {
+		return link
+	}
+	dir, target := dp[5:len(dp)-1], lp[5:]
+	i := 0
+	for i < len(dir) && i < len(target)-1 && dir[i] == target[i] {
+		i++
+	}
+	rel := &url.URL{
+		Path:     strings.Repeat("../", len(dir)-i) + strings.Join(target[i:], "/"),
+		RawQuery: l.RawQuery,
+		Fragment: l.Fragment,
+	}
+	return rel.String()
+}
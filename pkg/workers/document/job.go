@@ -9,12 +9,14 @@ import (
 	"fmt"
 	"sync"
 
-	"github.com/gardener/docforge/cmd/hugo"
 	"github.com/gardener/docforge/pkg/manifest"
 	"github.com/gardener/docforge/pkg/readers/repositoryhosts"
+	"github.com/gardener/docforge/pkg/workers/anchorvalidator"
+	"github.com/gardener/docforge/pkg/workers/document/markdown"
 	"github.com/gardener/docforge/pkg/workers/downloader"
 	"github.com/gardener/docforge/pkg/workers/linkresolver"
 	"github.com/gardener/docforge/pkg/workers/linkvalidator"
+	"github.com/gardener/docforge/pkg/workers/searchindex"
 	"github.com/gardener/docforge/pkg/workers/taskqueue"
 	"github.com/gardener/docforge/pkg/writers"
 	"k8s.io/klog/v2"
@@ -32,22 +34,28 @@ type Processor interface {
 }
 
 // New creates a new Worker
-func New(workerCount int, failFast bool, wg *sync.WaitGroup, structure []*manifest.Node, resourcesRoot string, downloadJob downloader.Interface, validator linkvalidator.Interface, rh repositoryhosts.Registry, hugo hugo.Hugo, writer writers.Writer) (Processor, taskqueue.QueueController, error) {
+func New(workerCount int, failFast bool, wg *sync.WaitGroup, structure []*manifest.Node, resourcesRoot string, downloadJob downloader.Interface, validator linkvalidator.Interface, anchors anchorvalidator.Interface, search searchindex.Interface, rh repositoryhosts.Registry, options Options, writer writers.Writer) (Processor, taskqueue.QueueController, error) {
 	lr := &linkresolver.LinkResolver{
 		Repositoryhosts: rh,
-		Hugo:            hugo,
+		Hugo:            options.Hugo,
+		MkDocs:          options.MkDocs,
+		Docusaurus:      options.Docusaurus,
+		HTML:            options.HTML,
 		SourceToNode:    make(map[string][]*manifest.Node),
 	}
 	for _, node := range structure {
+		// nodes with a fragment of a source are linked by the source URL
 		if node.Source != "" {
-			lr.SourceToNode[node.Source] = append(lr.SourceToNode[node.Source], node)
+			source, _ := markdown.SplitFragment(node.Source)
+			lr.SourceToNode[source] = append(lr.SourceToNode[source], node)
 		} else if len(node.MultiSource) > 0 {
 			for _, s := range node.MultiSource {
-				lr.SourceToNode[s] = append(lr.SourceToNode[s], node)
+				source, _ := markdown.SplitFragment(s)
+				lr.SourceToNode[source] = append(lr.SourceToNode[source], node)
 			}
 		}
 	}
-	worker := NewDocumentWorker(resourcesRoot, downloadJob, validator, lr, rh, hugo, writer)
+	worker := NewDocumentWorker(resourcesRoot, downloadJob, validator, anchors, search, lr, rh, options, writer)
 	queue, err := taskqueue.New("Document", workerCount, worker.execute, failFast, wg)
 	if err != nil {
 		return nil, nil, err
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+var (
+	htmlAnchorRgx = regexp.MustCompile(`\s(?:id|name)\s*=\s*["']([^"']+)["']`)
+)
+
+// Link is a link of a document
+type Link struct {
+	// Destination is the link destination
+	Destination string
+	// Line is the line the link starts on
+	Line int
+}
+
+// Anchors returns the anchors of a document: the GitHub and Hugo anchors of the headings and the
+// HTML id and name attributes. The heading anchors are deduplicated within githubIDs and hugoIDs
+func Anchors(doc ast.Node, source []byte, githubIDs map[string]int, hugoIDs map[string]int) []string {
+	var anchors []string
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch t := n.(type) {
+		case *ast.Heading:
+			text := HeadingText(t, source)
+			anchors = append(anchors, uniqueID(Slug(text, GitHubSlugs), githubIDs), uniqueID(Slug(text, HugoSlugs), hugoIDs))
+			return ast.WalkSkipChildren, nil
+		case *ast.HTMLBlock:
+			var b bytes.Buffer
+			for i := 0; i < t.Lines().Len(); i++ {
+				segment := t.Lines().At(i)
+				b.Write(segment.Value(source))
+			}
+			anchors = append(anchors, htmlAnchors(b.String())...)
+		case *ast.RawHTML:
+			var b bytes.Buffer
+			for i := 0; i < t.Segments.Len(); i++ {
+				segment := t.Segments.At(i)
+				b.Write(segment.Value(source))
+			}
+			anchors = append(anchors, htmlAnchors(b.String())...)
+		}
+		return ast.WalkContinue, nil
+	})
+	return anchors
+}
+
+func htmlAnchors(html string) []string {
+	var anchors []string
+	for _, m := range htmlAnchorRgx.FindAllStringSubmatch(html, -1) {
+		anchors = append(anchors, m[1])
+	}
+	return anchors
+}
+
+// Links returns the links of a document with the line they start on
+func Links(doc ast.Node, source []byte) []Link {
+	var links []Link
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if l, ok := n.(*ast.Link); ok && entering {
+			links = append(links, Link{Destination: string(l.Destination), Line: line(l, source)})
+		}
+		return ast.WalkContinue, nil
+	})
+	return links
+}
+
+// line returns the line a node starts on, or the line of its block if it has no text
+func line(n ast.Node, source []byte) int {
+	for ; n != nil; n = n.Parent() {
+		if start, ok := blockStart(n); ok {
+			return bytes.Count(source[:start], []byte("\n")) + 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,221 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+var (
+	lineRangeRgx = regexp.MustCompile(`^L(\d+)(?:-L(\d+))?$`)
+	regionRgx    = regexp.MustCompile(`^<!--\s*docforge:(region|endregion)\s*(\S*)\s*-->$`)
+)
+
+// SplitFragment splits a source URL into the document URL and the selected fragment
+func SplitFragment(source string) (string, string) {
+	if i := strings.Index(source, "#"); i >= 0 {
+		return source[:i], source[i+1:]
+	}
+	return source, ""
+}
+
+// SelectFragment keeps only the top-level blocks of the document selected by the fragment:
+//   - `L10-L80` selects the blocks starting on lines 10 to 80, `L10` the block starting on line 10
+//   - `region=name` selects the blocks between `<!-- docforge:region name -->` and `<!-- docforge:endregion -->`
+//   - any other fragment is a heading anchor and selects the heading and its content up to the next heading of the same or higher level
+func SelectFragment(doc ast.Node, source []byte, fragment string) error {
+	var (
+		selected []ast.Node
+		err      error
+	)
+	switch {
+	case lineRangeRgx.MatchString(fragment):
+		selected, err = selectLines(doc, source, fragment)
+	case strings.HasPrefix(fragment, "region="):
+		selected, err = selectRegion(doc, source, strings.TrimPrefix(fragment, "region="))
+	default:
+		selected, err = selectSection(doc, source, fragment)
+	}
+	if err != nil {
+		return err
+	}
+	keep := map[ast.Node]bool{}
+	for _, n := range selected {
+		keep[n] = true
+	}
+	for c := doc.FirstChild(); c != nil; {
+		next := c.NextSibling()
+		if !keep[c] {
+			doc.RemoveChild(doc, c)
+		}
+		c = next
+	}
+	return nil
+}
+
+func selectLines(doc ast.Node, source []byte, fragment string) ([]ast.Node, error) {
+	m := lineRangeRgx.FindStringSubmatch(fragment)
+	from, _ := strconv.Atoi(m[1])
+	to := from
+	if m[2] != "" {
+		to, _ = strconv.Atoi(m[2])
+	}
+	if to < from {
+		return nil, fmt.Errorf("invalid line range %s", fragment)
+	}
+	var selected []ast.Node
+	for c := doc.FirstChild(); c != nil; c = c.NextSibling() {
+		start, ok := blockStart(c)
+		if !ok {
+			continue
+		}
+		if line := bytes.Count(source[:start], []byte("\n")) + 1; line >= from && line <= to {
+			selected = append(selected, c)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no content on lines %s", fragment)
+	}
+	return selected, nil
+}
+
+func selectRegion(doc ast.Node, source []byte, name string) ([]ast.Node, error) {
+	var (
+		selected []ast.Node
+		inRegion bool
+	)
+	for c := doc.FirstChild(); c != nil; c = c.NextSibling() {
+		marker, markerName := regionMarker(c, source)
+		switch {
+		case !inRegion && marker == "region" && markerName == name:
+			inRegion = true
+		case inRegion && marker == "endregion" && (markerName == "" || markerName == name):
+			return selected, nil
+		case inRegion:
+			selected = append(selected, c)
+		}
+	}
+	if inRegion {
+		return nil, fmt.Errorf("region %s has no end marker", name)
+	}
+	return nil, fmt.Errorf("region %s not found", name)
+}
+
+func selectSection(doc ast.Node, source []byte, anchor string) ([]ast.Node, error) {
+	var (
+		selected []ast.Node
+		level    int
+	)
+	ids := map[string]int{}
+	for c := doc.FirstChild(); c != nil; c = c.NextSibling() {
+		h, isHeading := c.(*ast.Heading)
+		if level > 0 {
+			if isHeading && h.Level <= level {
+				break
+			}
+			selected = append(selected, c)
+			continue
+		}
+		if isHeading && uniqueID(Slug(HeadingText(h, source), GitHubSlugs), ids) == anchor {
+			level = h.Level
+			selected = append(selected, c)
+		}
+	}
+	if level == 0 {
+		return nil, fmt.Errorf("heading #%s not found", anchor)
+	}
+	return selected, nil
+}
+
+// regionMarker returns the marker and name of a region comment
+func regionMarker(n ast.Node, source []byte) (string, string) {
+	if n.Kind() != ast.KindHTMLBlock {
+		return "", ""
+	}
+	var b bytes.Buffer
+	lines := n.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		segment := lines.At(i)
+		b.Write(segment.Value(source))
+	}
+	m := regionRgx.FindStringSubmatch(strings.TrimSpace(b.String()))
+	if m == nil {
+		return "", ""
+	}
+	return m[1], m[2]
+}
+
+// blockStart returns the offset of the first source segment of a block
+func blockStart(n ast.Node) (int, bool) {
+	if fcb, ok := n.(*ast.FencedCodeBlock); ok {
+		if fcb.Info != nil {
+			return fcb.Info.Segment.Start, true
+		}
+		if fcb.Lines().Len() > 0 {
+			// the opening fence is on the line before the code
+			return fcb.Lines().At(0).Start - 1, true
+		}
+	}
+	if n.Type() == ast.TypeBlock && n.Lines().Len() > 0 {
+		return n.Lines().At(0).Start, true
+	}
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		if start, ok := blockStart(c); ok {
+			return start, true
+		}
+	}
+	if t, ok := n.(*ast.Text); ok {
+		return t.Segment.Start, true
+	}
+	return 0, false
+}
+
+// SlugMode selects how heading texts are converted to anchors
+type SlugMode int
+
+const (
+	// GitHubSlugs are the anchors of GitHub
+	GitHubSlugs SlugMode = iota
+	// HugoSlugs are the anchors of Hugo, which sets the anchor of a heading text ending with a `{#id}` attribute
+	HugoSlugs
+)
+
+var headingIDRgx = regexp.MustCompile(`\s*\{#([^}\s]+)\}$`)
+
+// Slug returns the anchor of a heading text in the mode
+func Slug(text string, mode SlugMode) string {
+	if mode == HugoSlugs {
+		if m := headingIDRgx.FindStringSubmatch(text); m != nil {
+			return m[1]
+		}
+	}
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(text)) {
+		switch {
+		case r == ' ' || mode == HugoSlugs && unicode.IsSpace(r):
+			b.WriteRune('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// uniqueID returns the id deduplicated with a numeric suffix as GitHub does
+func uniqueID(id string, ids map[string]int) string {
+	count, ok := ids[id]
+	ids[id] = count + 1
+	if !ok {
+		return id
+	}
+	return fmt.Sprintf("%s-%d", id, count)
+}
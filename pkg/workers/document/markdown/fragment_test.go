@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package markdown_test
+
+import (
+	"bytes"
+
+	"github.com/gardener/docforge/pkg/workers/document/markdown"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Fragments", func() {
+	const md = "# Title\n\nIntro\n\n## Install\n\nRun it.\n\n### Options\n\n```bash\nrun --all\n```\n\n## Install\n\nAgain.\n\n<!-- docforge:region usage -->\n\n- one\n- two\n\n<!-- docforge:endregion -->\n\nEnd.\n"
+
+	DescribeTable("selecting fragments",
+		func(fragment string, expected string) {
+			doc, err := markdown.Parse([]byte(md))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(markdown.SelectFragment(doc, []byte(md), fragment)).To(Succeed())
+			buf := &bytes.Buffer{}
+			Expect(markdown.NewLinkModifierRenderer().Render(buf, []byte(md), doc)).To(Succeed())
+			Expect(buf.String()).To(Equal(expected))
+		},
+		Entry("heading section", "install", "## Install\n\nRun it.\n\n### Options\n\n```bash\nrun --all\n```\n"),
+		Entry("duplicated heading section", "install-1", "## Install\n\nAgain.\n\n<!-- docforge:region usage -->\n\n- one\n- two\n\n<!-- docforge:endregion -->\n\nEnd.\n"),
+		Entry("line range", "L7-L13", "Run it.\n\n### Options\n\n```bash\nrun --all\n```\n"),
+		Entry("single line", "L3", "Intro\n"),
+		Entry("region", "region=usage", "- one\n- two\n"),
+	)
+
+	DescribeTable("failing fragments",
+		func(fragment string, message string) {
+			doc, err := markdown.Parse([]byte(md))
+			Expect(err).NotTo(HaveOccurred())
+			err = markdown.SelectFragment(doc, []byte(md), fragment)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(message))
+		},
+		Entry("missing heading", "missing", "heading #missing not found"),
+		Entry("missing region", "region=missing", "region missing not found"),
+		Entry("empty line range", "L100-L200", "no content on lines"),
+		Entry("inverted line range", "L9-L2", "invalid line range"),
+	)
+
+	It("splits the fragment of a source", func() {
+		source, fragment := markdown.SplitFragment("https://github.com/org/repo/blob/master/README.md#L1-L5")
+		Expect(source).To(Equal("https://github.com/org/repo/blob/master/README.md"))
+		Expect(fragment).To(Equal("L1-L5"))
+	})
+
+	It("builds GitHub slugs", func() {
+		Expect(markdown.Slug("Hello, World! (v1.2)", markdown.GitHubSlugs)).To(Equal("hello-world-v12"))
+		Expect(markdown.Slug("snake_case and-dash", markdown.GitHubSlugs)).To(Equal("snake_case-and-dash"))
+		Expect(markdown.Slug("Options {#opts}", markdown.GitHubSlugs)).To(Equal("options-opts"))
+	})
+})
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package markdown
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+)
+
+// Heading is a document heading with its anchor
+type Heading struct {
+	Node *ast.Heading
+	ID   string
+}
+
+// Headings returns the document headings in order. The anchors are deduplicated within ids,
+// so headings of concatenated documents get the anchors of the resulting document
+func Headings(doc ast.Node, source []byte, ids map[string]int) []Heading {
+	var headings []Heading
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if h, ok := n.(*ast.Heading); ok && entering {
+			headings = append(headings, Heading{Node: h, ID: uniqueID(Slug(HeadingText(h, source), GitHubSlugs), ids)})
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return headings
+}
+
+// HeadingText returns the text of a heading. Line breaks of multiline headings are spaces
+func HeadingText(h *ast.Heading, source []byte) string {
+	var b bytes.Buffer
+	_ = ast.Walk(h, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch t := n.(type) {
+		case *ast.Text:
+			b.Write(t.Segment.Value(source))
+			if t.SoftLineBreak() || t.HardLineBreak() {
+				b.WriteByte(' ')
+			}
+		case *ast.String:
+			b.Write(t.Value)
+		}
+		return ast.WalkContinue, nil
+	})
+	return strings.TrimSpace(b.String())
+}
+
+// FirstH1 returns the first top-level heading of level 1 or nil
+func FirstH1(doc ast.Node) *ast.Heading {
+	for c := doc.FirstChild(); c != nil; c = c.NextSibling() {
+		if h, ok := c.(*ast.Heading); ok && h.Level == 1 {
+			return h
+		}
+	}
+	return nil
+}
+
+// FirstHeading returns the first top-level heading of any level of a document or nil
+func FirstHeading(doc ast.Node) *ast.Heading {
+	for c := doc.FirstChild(); c != nil; c = c.NextSibling() {
+		if h, ok := c.(*ast.Heading); ok {
+			return h
+		}
+	}
+	return nil
+}
+
+// InsertHeading inserts a heading with the given text at the top of the document
+func InsertHeading(doc ast.Node, level int, text string) *ast.Heading {
+	h := ast.NewHeading(level)
+	h.AppendChild(h, ast.NewString([]byte(text)))
+	if doc.FirstChild() == nil {
+		doc.AppendChild(doc, h)
+		return h
+	}
+	next := doc.FirstChild()
+	doc.InsertBefore(doc, next, h)
+	next.SetBlankPreviousLines(true)
+	return h
+}
+
+// HeadingOffset is an option name used in WithHeadingOffset
+const optHeadingOffset renderer.OptionName = "HeadingOffset"
+
+type withHeadingOffset struct {
+	value int
+}
+
+func (o *withHeadingOffset) SetConfig(c *renderer.Config) {
+	c.Options[optHeadingOffset] = o.value
+}
+
+// WithHeadingOffset is a functional option that demotes the rendered headings by offset levels, up to level 6
+func WithHeadingOffset(offset int) renderer.Option {
+	return &withHeadingOffset{offset}
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package markdown_test
+
+import (
+	"bytes"
+
+	"github.com/gardener/docforge/pkg/workers/document/markdown"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Headings", func() {
+	const md = "# Title\n\nIntro\n\n## Install\n\n> ### Quoted\n\nSetext\nheading\n---\n\n## Install\n"
+
+	DescribeTable("demoting headings",
+		func(offset int, expected string) {
+			doc, err := markdown.Parse([]byte(md))
+			Expect(err).NotTo(HaveOccurred())
+			buf := &bytes.Buffer{}
+			Expect(markdown.NewLinkModifierRenderer(markdown.WithHeadingOffset(offset)).Render(buf, []byte(md), doc)).To(Succeed())
+			Expect(buf.String()).To(Equal(expected))
+		},
+		Entry("no offset", 0, md),
+		Entry("one level", 1, "## Title\n\nIntro\n\n### Install\n\n> #### Quoted\n\n### Setext heading\n\n### Install\n"),
+		Entry("up to level 6", 4, "##### Title\n\nIntro\n\n###### Install\n\n> ###### Quoted\n\n###### Setext heading\n\n###### Install\n"),
+	)
+
+	It("returns the heading anchors", func() {
+		doc, err := markdown.Parse([]byte(md))
+		Expect(err).NotTo(HaveOccurred())
+		ids := map[string]int{"title": 1}
+		var anchors []string
+		for _, h := range markdown.Headings(doc, []byte(md), ids) {
+			anchors = append(anchors, h.ID)
+		}
+		Expect(anchors).To(Equal([]string{"title-1", "install", "quoted", "setext-heading", "install-1"}))
+	})
+
+	It("replaces the first H1 with a generated heading", func() {
+		doc, err := markdown.Parse([]byte(md))
+		Expect(err).NotTo(HaveOccurred())
+		h1 := markdown.FirstH1(doc)
+		Expect(string(h1.Text([]byte(md)))).To(Equal("Title"))
+		doc.RemoveChild(doc, h1)
+		markdown.InsertHeading(doc, 1, "Generated")
+		buf := &bytes.Buffer{}
+		Expect(markdown.NewLinkModifierRenderer().Render(buf, []byte(md), doc)).To(Succeed())
+		Expect(buf.String()).To(HavePrefix("# Generated\n\nIntro\n\n## Install\n"))
+	})
+})
+
+var _ = Describe("Anchors", func() {
+	const md = "# Title\n\n## Setup {#explicit}\n\n## Title\n\n<a name=\"legacy\"></a>\nSee [setup](#explicit) and\n[other](./other.md#usage).\n"
+
+	It("returns the GitHub, Hugo and HTML anchors", func() {
+		doc, err := markdown.Parse([]byte(md))
+		Expect(err).NotTo(HaveOccurred())
+		anchors := markdown.Anchors(doc, []byte(md), map[string]int{}, map[string]int{})
+		Expect(anchors).To(Equal([]string{"title", "title", "setup-explicit", "explicit", "title-1", "title-1", "legacy"}))
+	})
+
+	It("returns the links with their line", func() {
+		doc, err := markdown.Parse([]byte(md))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(markdown.Links(doc, []byte(md))).To(Equal([]markdown.Link{
+			{Destination: "#explicit", Line: 8},
+			{Destination: "./other.md#usage", Line: 9},
+		}))
+	})
+
+	DescribeTable("Hugo anchors",
+		func(text string, expected string) {
+			Expect(markdown.Slug(text, markdown.HugoSlugs)).To(Equal(expected))
+		},
+		Entry("lower case words", "Getting Started", "getting-started"),
+		Entry("punctuation", "What's new?", "whats-new"),
+		Entry("explicit anchor", "Options {#opts}", "opts"),
+	)
+})
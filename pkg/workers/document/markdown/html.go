@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package markdown
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/util"
+	"gopkg.in/yaml.v3"
+)
+
+// A htmlRenderer struct is an implementation of renderer.Renderer interface rendering documents as HTML.
+type htmlRenderer struct {
+	config *renderer.Config
+}
+
+// NewHTMLRenderer returns a renderer of the documents as HTML with their raw HTML, as GitHub renders them.
+// The links, snippets and heading offset options are applied as the link modifier renderer applies them, and
+// the headings get their id attribute. The document front matter is kept as a YAML front matter block
+func NewHTMLRenderer(opts ...renderer.Option) renderer.Renderer {
+	config := renderer.NewConfig()
+	for _, opt := range opts {
+		opt.SetConfig(config)
+	}
+	if _, ok := config.Options[optLinkResolver]; !ok {
+		WithLinkResolver(resolveSame).SetConfig(config)
+	}
+	return &htmlRenderer{
+		config: config,
+	}
+}
+
+func (h *htmlRenderer) AddOptions(opts ...renderer.Option) {
+	for _, opt := range opts {
+		opt.SetConfig(h.config)
+	}
+}
+
+func (h *htmlRenderer) Render(w io.Writer, source []byte, node ast.Node) error {
+	r := &Renderer{
+		source:       source,
+		linkResolver: h.config.Options[optLinkResolver].(ResolveLink),
+	}
+	if offset, ok := h.config.Options[optHeadingOffset].(int); ok {
+		r.headingOffset = offset
+	}
+	if resolver, ok := h.config.Options[optSnippetResolver].(ResolveSnippet); ok {
+		r.snippetResolver = resolver
+	}
+	if doc, ok := node.(*ast.Document); ok && len(doc.Meta()) > 0 {
+		fm, err := yaml.Marshal(doc.Meta())
+		if err != nil {
+			return err
+		}
+		_, _ = w.Write([]byte("---\n"))
+		_, _ = w.Write(fm)
+		_, _ = w.Write([]byte("---\n"))
+	}
+	// the link destinations and heading levels are rendered from the document
+	err := ast.Walk(node, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := node.(type) {
+		case *ast.Link:
+			dest, err := r.linkResolver(string(n.Destination), false)
+			if err != nil {
+				return ast.WalkStop, err
+			}
+			n.Destination = []byte(dest)
+		case *ast.Image:
+			dest, err := r.linkResolver(string(n.Destination), true)
+			if err != nil {
+				return ast.WalkStop, err
+			}
+			n.Destination = []byte(dest)
+		case *ast.Heading:
+			n.Level = min(n.Level+r.headingOffset, 6)
+		}
+		return ast.WalkContinue, nil
+	})
+	if err != nil {
+		return err
+	}
+	rnd := renderer.NewRenderer(renderer.WithNodeRenderers(
+		util.Prioritized(html.NewRenderer(html.WithUnsafe()), 1000),
+		util.Prioritized(extension.NewTableHTMLRenderer(), 500),
+		util.Prioritized(extension.NewStrikethroughHTMLRenderer(), 500),
+		util.Prioritized(extension.NewTaskCheckBoxHTMLRenderer(), 500),
+		util.Prioritized(&htmlNodeRenderer{r}, 100),
+	))
+	return rnd.Render(w, source, node)
+}
+
+// htmlNodeRenderer renders the raw HTML with resolved links and the code blocks with their snippets
+type htmlNodeRenderer struct {
+	*Renderer
+}
+
+func (h *htmlNodeRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindHTMLBlock, h.renderHTMLBlock)
+	reg.Register(ast.KindRawHTML, h.renderRawHTML)
+	reg.Register(ast.KindFencedCodeBlock, h.renderCodeBlock)
+	reg.Register(ast.KindCodeBlock, h.renderCodeBlock)
+}
+
+func (h *htmlNodeRenderer) renderHTMLBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	n := node.(*ast.HTMLBlock)
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	var b bytes.Buffer
+	h.writeSegments(&b, n.Lines(), false)
+	if n.HasClosure() {
+		b.Write(n.ClosureLine.Value(source))
+	}
+	// HTMLBlockType 6 & 7 may contain links and images
+	if n.HTMLBlockType >= ast.HTMLBlockType6 {
+		return ast.WalkSkipChildren, h.writeHTML(w, b.Bytes())
+	}
+	_, _ = w.Write(b.Bytes())
+	return ast.WalkSkipChildren, nil
+}
+
+func (h *htmlNodeRenderer) renderRawHTML(w util.BufWriter, _ []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	n := node.(*ast.RawHTML)
+	if !entering {
+		return ast.WalkSkipChildren, nil
+	}
+	var b bytes.Buffer
+	h.writeSegments(&b, n.Segments, false)
+	return ast.WalkSkipChildren, h.writeHTML(w, b.Bytes())
+}
+
+// writeHTML writes raw HTML with the links of its link and image tags resolved
+func (h *htmlNodeRenderer) writeHTML(w util.BufWriter, content []byte) error {
+	var b bytes.Buffer
+	modified, err := h.modifyHTMLTags(content, &b)
+	if err != nil {
+		return err
+	}
+	if modified {
+		content = b.Bytes()
+	}
+	_, _ = w.Write(content)
+	return nil
+}
+
+func (h *htmlNodeRenderer) renderCodeBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	lines, err := h.codeLines(node)
+	if err != nil {
+		return ast.WalkStop, err
+	}
+	var content bytes.Buffer
+	for _, l := range lines {
+		content.Write(l)
+	}
+	_, _ = w.WriteString("<pre><code")
+	if n, ok := node.(*ast.FencedCodeBlock); ok {
+		if language := n.Language(source); language != nil {
+			_, _ = w.WriteString(` class="language-`)
+			html.DefaultWriter.Write(w, language)
+			_ = w.WriteByte('"')
+			if bytes.Equal(language, []byte("mermaid")) {
+				// resolve links in mermaid diagrams
+				var b bytes.Buffer
+				modified, err := h.modifyMermaid(content.Bytes(), &b)
+				if err != nil {
+					return ast.WalkStop, err
+				}
+				if modified {
+					content = b
+				}
+			}
+		}
+	}
+	_ = w.WriteByte('>')
+	html.DefaultWriter.RawWrite(w, content.Bytes())
+	_, _ = w.WriteString("</code></pre>\n")
+	return ast.WalkSkipChildren, nil
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package markdown_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	"github.com/gardener/docforge/pkg/workers/document/markdown"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yuin/goldmark/renderer"
+)
+
+var _ = Describe("HTML renderer", func() {
+	var (
+		md   string
+		opts []renderer.Option
+		buf  *bytes.Buffer
+		err  error
+	)
+
+	BeforeEach(func() {
+		resolve := func(dest string, isEmbeddable bool) (string, error) {
+			if isEmbeddable {
+				return "../__resources/" + dest, nil
+			}
+			return strings.TrimSuffix(dest, ".md") + ".html", nil
+		}
+		opts = []renderer.Option{markdown.WithLinkResolver(resolve)}
+	})
+
+	JustBeforeEach(func() {
+		doc, parseErr := markdown.Parse([]byte(md))
+		Expect(parseErr).NotTo(HaveOccurred())
+		buf = &bytes.Buffer{}
+		err = markdown.NewHTMLRenderer(opts...).Render(buf, []byte(md), doc)
+	})
+
+	Context("with front matter and headings", func() {
+		BeforeEach(func() {
+			md = "---\ntitle: Guide\n---\n# Guide\n\n## Install\n"
+			opts = append(opts, markdown.WithHeadingOffset(1))
+		})
+
+		It("keeps the front matter and demotes the headings", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buf.String()).To(Equal("---\ntitle: Guide\n---\n<h2>Guide</h2>\n<h3>Install</h3>\n"))
+		})
+	})
+
+	Context("with links", func() {
+		BeforeEach(func() {
+			md = "See [install](install.md) ![logo](logo.png) <a href=\"setup.md\">setup</a>.\n\n<div>\n<img src=\"arch.png\">\n</div>\n\n| a | b |\n|---|---|\n| ~~c~~ | d |\n"
+		})
+
+		It("renders the resolved links", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buf.String()).To(Equal("<p>See <a href=\"install.html\">install</a> <img src=\"../__resources/logo.png\" alt=\"logo\"> <a href=\"setup.html\">setup</a>.</p>\n" +
+				"<div>\n<img src=\"../__resources/arch.png\">\n</div>\n" +
+				"<table>\n<thead>\n<tr>\n<th>a</th>\n<th>b</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td><del>c</del></td>\n<td>d</td>\n</tr>\n</tbody>\n</table>\n"))
+		})
+	})
+
+	Context("with code blocks", func() {
+		BeforeEach(func() {
+			md = "```go {source=\"main.go\"}\n```\n\n    <b>\n"
+			resolve := func(snippet markdown.Snippet) ([]byte, error) {
+				return []byte("if a < b {\n}\n"), nil
+			}
+			opts = append(opts, markdown.WithSnippetResolver(resolve))
+		})
+
+		It("renders the escaped snippets", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buf.String()).To(Equal("<pre><code class=\"language-go\">if a &lt; b {\n}\n</code></pre>\n<pre><code>&lt;b&gt;\n</code></pre>\n"))
+		})
+	})
+
+	Context("with a failing link resolver", func() {
+		BeforeEach(func() {
+			md = "[install](install.md)\n"
+			opts = append(opts, markdown.WithLinkResolver(func(string, bool) (string, error) {
+				return "", errors.New("fake error")
+			}))
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError("fake error"))
+		})
+	})
+})
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+var includeRgx = regexp.MustCompile(`^<!--\s*docforge:include\s+(\S+)(?:\s+headingOffset=(\d+))?\s*-->\s*$`)
+
+// Include is a transclusion directive `<!-- docforge:include ./other.md headingOffset=1 -->`
+type Include struct {
+	// Link is the link to the included document
+	Link string
+	// HeadingOffset demotes the headings of the included document
+	HeadingOffset int
+	// Start is the offset of the directive in the source
+	Start int
+	// Stop is the offset after the directive line in the source
+	Stop int
+}
+
+// Includes returns the transclusion directives of a document in order. Only directives
+// that are top-level blocks on their own are considered
+func Includes(doc ast.Node, source []byte) []Include {
+	var includes []Include
+	for c := doc.FirstChild(); c != nil; c = c.NextSibling() {
+		h, ok := c.(*ast.HTMLBlock)
+		if !ok || h.Lines().Len() == 0 {
+			continue
+		}
+		var b bytes.Buffer
+		for i := 0; i < h.Lines().Len(); i++ {
+			segment := h.Lines().At(i)
+			b.Write(segment.Value(source))
+		}
+		m := includeRgx.FindSubmatch(b.Bytes())
+		if m == nil {
+			continue
+		}
+		offset, _ := strconv.Atoi(string(m[2]))
+		includes = append(includes, Include{
+			Link:          string(m[1]),
+			HeadingOffset: offset,
+			Start:         h.Lines().At(0).Start,
+			Stop:          h.Lines().At(h.Lines().Len() - 1).Stop,
+		})
+	}
+	return includes
+}
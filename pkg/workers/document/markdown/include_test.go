@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package markdown_test
+
+import (
+	"github.com/gardener/docforge/pkg/workers/document/markdown"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Includes", func() {
+	It("returns the top-level transclusion directives", func() {
+		md := "# Title\n\n<!-- docforge:include ./prereqs.md -->\n\n" +
+			"Text with `<!-- docforge:include ./code.md -->`.\n\n" +
+			"- <!-- docforge:include ./nested.md -->\n\n" +
+			"<!--docforge:include ../other.md#setup headingOffset=2-->\n"
+		doc, err := markdown.Parse([]byte(md))
+		Expect(err).NotTo(HaveOccurred())
+		includes := markdown.Includes(doc, []byte(md))
+		Expect(includes).To(HaveLen(2))
+		Expect(includes[0].Link).To(Equal("./prereqs.md"))
+		Expect(includes[0].HeadingOffset).To(Equal(0))
+		Expect(md[includes[0].Start:includes[0].Stop]).To(Equal("<!-- docforge:include ./prereqs.md -->\n"))
+		Expect(includes[1].Link).To(Equal("../other.md#setup"))
+		Expect(includes[1].HeadingOffset).To(Equal(2))
+		Expect(md[includes[1].Start:includes[1].Stop]).To(Equal("<!--docforge:include ../other.md#setup headingOffset=2-->\n"))
+	})
+})
@@ -102,6 +102,21 @@ func (l *linkModifierRenderer) Render(w io.Writer, source []byte, node ast.Node)
 		markers:      make([]int, 0, 5),
 		emphasis:     make([]byte, 0, 5),
 	}
+	if offset, ok := l.config.Options[optHeadingOffset].(int); ok {
+		r.headingOffset = offset
+	}
+	if resolver, ok := l.config.Options[optSnippetResolver].(ResolveSnippet); ok {
+		r.snippetResolver = resolver
+	}
+	if shortcodes, ok := l.config.Options[optShortcodeParams].(ShortcodeParams); ok {
+		r.shortcodes = shortcodes
+	}
+	if resolver, ok := l.config.Options[optContentLinkResolver].(ResolveLink); ok {
+		r.contentLinkResolver = resolver
+	}
+	if mdx, ok := l.config.Options[optMDX].(bool); ok {
+		r.mdx = mdx
+	}
 	writer, ok := w.(*bytes.Buffer)
 	if ok {
 		r.writer = writer
@@ -176,10 +191,22 @@ type Renderer struct {
 	source       []byte
 	writer       *bytes.Buffer
 	linkResolver ResolveLink
-	indents      []byte
-	markers      []int
-	emphasis     []byte
-	table        bool
+	// snippetResolver fills the code blocks embedding snippets, if set
+	snippetResolver ResolveSnippet
+	// shortcodes are the shortcode parameters with links to resolve
+	shortcodes ShortcodeParams
+	// contentLinkResolver resolves the links of the shortcodes referring to content paths, if set
+	contentLinkResolver ResolveLink
+	// mdx escapes the content that MDX doesn't compile
+	mdx      bool
+	indents  []byte
+	markers  []int
+	emphasis []byte
+	table    bool
+	// headingOffset demotes the headings
+	headingOffset int
+	// inlineHeading is set while rendering a multiline heading on a single line
+	inlineHeading bool
 }
 
 // --------------------------- Node Renders
@@ -255,20 +282,24 @@ func (r *Renderer) renderListItem(node ast.Node, entering bool) (ast.WalkStatus,
 
 func (r *Renderer) renderHeading(node ast.Node, entering bool) (ast.WalkStatus, error) {
 	n := node.(*ast.Heading)
+	level := min(n.Level+r.headingOffset, 6)
 	atx := true // defaults to ATX headings
-	if n.Lines().Len() > 1 && n.Level <= 2 {
+	if n.Lines().Len() > 1 && level <= 2 {
 		atx = false // multiline heading -> use Setext headings
 	}
 	if entering {
 		r.blockSeparator(n)
 		if atx {
-			_, _ = r.writer.Write(bytes.Repeat([]byte{'#'}, n.Level))
+			_, _ = r.writer.Write(bytes.Repeat([]byte{'#'}, level))
 			_ = r.writer.WriteByte(' ')
+			// demoted multiline Setext headings are joined
+			r.inlineHeading = n.Lines().Len() > 1
 		}
 	} else {
+		r.inlineHeading = false
 		if !atx {
 			r.newLine(true)
-			if n.Level == 1 {
+			if level == 1 {
 				_, _ = r.writer.Write([]byte{'=', '=', '='})
 			} else {
 				_, _ = r.writer.Write([]byte{'-', '-', '-'})
@@ -285,15 +316,21 @@ func (r *Renderer) renderFencedCodeBlock(n ast.Node, entering bool) (ast.WalkSta
 		buf.Reset()
 		indents := len(r.indents) > 0
 		var fb byte = '`'
-		segments := n.Lines()
-		for _, l := range segments.Sliced(0, segments.Len()) {
-			if fence.Match(l.Value(r.source)) {
+		lines, err := r.codeLines(n)
+		if err != nil {
+			return ast.WalkStop, err
+		}
+		for _, l := range lines {
+			if len(l) == 0 {
+				continue
+			}
+			if fence.Match(l) {
 				fb = '~'
 			}
 			if indents {
 				_, _ = buf.Write(r.indents)
 			}
-			_, _ = buf.Write(l.Value(r.source))
+			_, _ = buf.Write(l)
 		}
 		r.blockSeparator(n)
 		_, _ = r.writer.Write([]byte{fb, fb, fb})
@@ -333,6 +370,25 @@ func (r *Renderer) renderFencedCodeBlock(n ast.Node, entering bool) (ast.WalkSta
 	return ast.WalkSkipChildren, nil
 }
 
+// codeLines returns the lines of a code block, or the lines of the snippet it embeds
+func (r *Renderer) codeLines(n ast.Node) ([][]byte, error) {
+	var lines [][]byte
+	segments := n.Lines()
+	for _, l := range segments.Sliced(0, segments.Len()) {
+		lines = append(lines, l.Value(r.source))
+	}
+	if fn, ok := n.(*ast.FencedCodeBlock); ok && fn.Info != nil && r.snippetResolver != nil {
+		if snippet, ok := ParseSnippet(fn.Info.Segment.Value(r.source)); ok {
+			content, err := r.snippetResolver(snippet)
+			if err != nil {
+				return nil, err
+			}
+			lines = bytes.SplitAfter(content, []byte("\n"))
+		}
+	}
+	return lines, nil
+}
+
 func (r *Renderer) renderHTMLBlock(node ast.Node, entering bool) (ast.WalkStatus, error) {
 	n := node.(*ast.HTMLBlock)
 	if entering {
@@ -343,18 +399,34 @@ func (r *Renderer) renderHTMLBlock(node ast.Node, entering bool) (ast.WalkStatus
 			defer bufPool.Put(buf)
 			buf.Reset()
 			r.writeSegments(buf, n.Lines(), false)
+			content, _, err := r.modifyShortcodes(buf.Bytes())
+			if err != nil {
+				return ast.WalkStop, err
+			}
 			// modify
 			modBuf := bufPool.Get().(*bytes.Buffer)
 			defer bufPool.Put(modBuf)
 			modBuf.Reset()
-			modified, err := r.modifyHTMLTags(buf.Bytes(), modBuf)
+			modified, err := r.modifyHTMLTags(content, modBuf)
 			if err != nil {
 				return ast.WalkStop, err
 			}
 			if modified {
-				buf = modBuf
+				content = modBuf.Bytes()
 			}
-			r.writeContent(buf.Bytes())
+			if r.mdx {
+				content = mdxHTML(content)
+			}
+			r.writeContent(content)
+		} else if r.mdx {
+			buf := bufPool.Get().(*bytes.Buffer)
+			defer bufPool.Put(buf)
+			buf.Reset()
+			r.writeSegments(buf, n.Lines(), false)
+			if n.HasClosure() {
+				buf.Write(n.ClosureLine.Value(r.source))
+			}
+			r.writeContent(mdxHTML(buf.Bytes()))
 		} else {
 			r.writeSegments(r.writer, n.Lines(), len(r.indents) > 0)
 			// HTMLBlockType 1 to 5 end condition is not blank line
@@ -571,15 +643,34 @@ func (r *Renderer) renderRawHTML(node ast.Node, entering bool) (ast.WalkStatus,
 		if modified {
 			buf = modBuf
 		}
-		r.writeContent(buf.Bytes())
+		if r.mdx {
+			r.writeContent(mdxHTML(buf.Bytes()))
+		} else {
+			r.writeContent(buf.Bytes())
+		}
 	}
 	return ast.WalkSkipChildren, nil
 }
 
 func (r *Renderer) renderText(node ast.Node, entering bool) (ast.WalkStatus, error) {
 	if entering {
+		if s, ok := node.(*ast.String); ok {
+			// generated text
+			_, _ = r.writer.Write(s.Value)
+			return ast.WalkSkipChildren, nil
+		}
 		n := node.(*ast.Text)
+		if len(r.shortcodes) > 0 {
+			mergeShortcodeText(n, r.source)
+		}
 		txt := n.Text(r.source)
+		txt, _, err := r.modifyShortcodes(txt)
+		if err != nil {
+			return ast.WalkStop, err
+		}
+		if r.mdx {
+			txt = escapeMDXText(txt)
+		}
 		r.additionalIndents(txt, n)
 		if n.HardLineBreak() || n.SoftLineBreak() || nextIsLineBreak(node.NextSibling(), r.source) {
 			// trim trailing spaces
@@ -587,7 +678,9 @@ func (r *Renderer) renderText(node ast.Node, entering bool) (ast.WalkStatus, err
 		}
 		_, _ = r.writer.Write(txt)
 		indents := len(r.indents) > 0
-		if n.HardLineBreak() {
+		if r.inlineHeading && (n.HardLineBreak() || n.SoftLineBreak()) {
+			_ = r.writer.WriteByte(' ')
+		} else if n.HardLineBreak() {
 			_ = r.writer.WriteByte(' ')
 			_ = r.writer.WriteByte(' ')
 			r.newLine(indents)
@@ -772,7 +865,8 @@ func (r *Renderer) modifyHTMLTags(source []byte, target io.Writer) (bool, error)
 		t := z.Token()
 		if "a" == t.Data {
 			for i, a := range t.Attr {
-				if a.Key == "href" {
+				// shortcode calls are resolved separately
+				if a.Key == "href" && !strings.Contains(a.Val, "{{") {
 					dest, err := r.linkResolver(a.Val, false)
 					if err != nil {
 						return modified, err
@@ -786,7 +880,7 @@ func (r *Renderer) modifyHTMLTags(source []byte, target io.Writer) (bool, error)
 			}
 		} else if "img" == t.Data {
 			for i, a := range t.Attr {
-				if a.Key == "src" {
+				if a.Key == "src" && !strings.Contains(a.Val, "{{") {
 					dest, err := r.linkResolver(a.Val, true)
 					if err != nil {
 						return modified, err
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/yuin/goldmark/renderer"
+)
+
+var (
+	// defines HTML comments, which MDX doesn't support
+	htmlCommentRgx = regexp.MustCompile(`(?s)<!--(.*?)-->`)
+	// defines HTML void elements without a closing slash, e.g. `<br>` or `<img src="a.png">`
+	voidElementRgx = regexp.MustCompile(`(?i)<(area|base|br|col|embed|hr|img|input|link|meta|source|track|wbr)\b((?:[^>"']|"[^"]*"|'[^']*')*?)\s*>`)
+)
+
+// MDX is an option name used in WithMDX
+const optMDX renderer.OptionName = "MDX"
+
+type withMDX struct{}
+
+func (o *withMDX) SetConfig(c *renderer.Config) {
+	c.Options[optMDX] = true
+}
+
+// WithMDX is a functional option that renders markdown that MDX compiles, as used by Docusaurus.
+// Bare `<` and `{` in text are escaped, HTML comments become MDX comments and HTML void elements are closed
+func WithMDX() renderer.Option {
+	return &withMDX{}
+}
+
+// escapeMDXText escapes the characters of a text that MDX parses as JSX or expressions
+func escapeMDXText(txt []byte) []byte {
+	if bytes.IndexAny(txt, "<{") < 0 {
+		return txt
+	}
+	var b bytes.Buffer
+	for i, c := range txt {
+		if (c == '<' || c == '{') && !isEscaped(txt, i) {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	return b.Bytes()
+}
+
+// isEscaped returns true if the character at position i is preceded by an odd number of backslashes
+func isEscaped(txt []byte, i int) bool {
+	backslashes := 0
+	for j := i - 1; j >= 0 && txt[j] == '\\'; j-- {
+		backslashes++
+	}
+	return backslashes%2 == 1
+}
+
+// mdxHTML converts the HTML comments of raw HTML to MDX comments and closes its void elements
+func mdxHTML(content []byte) []byte {
+	content = htmlCommentRgx.ReplaceAll(content, []byte("{/*$1*/}"))
+	return voidElementRgx.ReplaceAllFunc(content, func(tag []byte) []byte {
+		if bytes.HasSuffix(tag, []byte("/>")) {
+			return tag
+		}
+		m := voidElementRgx.FindSubmatch(tag)
+		attrs := bytes.TrimRight(m[2], " \t\n")
+		return append(append(append([]byte("<"), m[1]...), attrs...), " />"...)
+	})
+}
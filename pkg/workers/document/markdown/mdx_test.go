@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package markdown_test
+
+import (
+	"bytes"
+
+	"github.com/gardener/docforge/pkg/workers/document/markdown"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = DescribeTable("Rendering MDX",
+	func(md string, expected string) {
+		doc, err := markdown.Parse([]byte(md))
+		Expect(err).NotTo(HaveOccurred())
+		buf := &bytes.Buffer{}
+		Expect(markdown.NewLinkModifierRenderer(markdown.WithMDX()).Render(buf, []byte(md), doc)).To(Succeed())
+		Expect(buf.String()).To(Equal(expected))
+	},
+	Entry("bare < and {", "If a < b use {name}.\n", "If a \\< b use \\{name}.\n"),
+	Entry("escaped characters", "Use \\{name} and \\\\{x}.\n", "Use \\{name} and \\\\\\{x}.\n"),
+	Entry("code", "Use `{name}` and\n\n```\na < b\n```\n", "Use `{name}` and\n\n```\na < b\n```\n"),
+	Entry("HTML comment block", "<!-- hidden\ncomment -->\n\nText\n", "{/* hidden\ncomment */}\n\nText\n"),
+	Entry("inline HTML comment", "Text <!-- hidden --> here\n", "Text {/* hidden */} here\n"),
+	Entry("void elements", "Line<br>break <img src=\"a.png\" alt=\"a > b\"> <br/>\n\n<div>\n<hr class=\"x\">\n</div>\n",
+		"Line<br />break <img src=\"a.png\" alt=\"a > b\" /> <br/>\n\n<div>\n<hr class=\"x\" />\n</div>\n"),
+)
@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+)
+
+// defines a shortcode argument, e.g. `src="img.png"`, `"../foo.md"` or `width=50`
+var shortcodeArgRgx = regexp.MustCompile(`(?:([\w-]+)=)?("(?:[^"\\]|\\.)*"|` + "`[^`]*`" + `|[^\s"=]+)`)
+
+// ShortcodeParams maps Hugo shortcodes to their link-bearing parameters. A parameter is either
+// a name or the position of an unnamed argument, starting at 0. Links in `src` parameters are embeddable
+type ShortcodeParams map[string][]string
+
+// DefaultShortcodeLinks are the link-bearing parameters of the Hugo built-in shortcodes
+var DefaultShortcodeLinks = []string{"ref:0", "relref:0", "figure:src", "figure:link"}
+
+// contentLinkShortcodes are the Hugo built-in shortcodes referring to content paths instead of URLs
+var contentLinkShortcodes = []string{"ref", "relref"}
+
+// ParseShortcodeParams parses `shortcode:parameter` entries
+func ParseShortcodeParams(entries []string) (ShortcodeParams, error) {
+	params := ShortcodeParams{}
+	for _, e := range entries {
+		name, param, ok := strings.Cut(e, ":")
+		if !ok || name == "" || param == "" {
+			return nil, fmt.Errorf("invalid shortcode parameter %s, expected shortcode:parameter", e)
+		}
+		params[name] = append(params[name], param)
+	}
+	return params, nil
+}
+
+func (p ShortcodeParams) isLink(shortcode string, name string, position int) bool {
+	for _, param := range p[shortcode] {
+		if param == name || (name == "" && param == strconv.Itoa(position)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ShortcodeParams is an option name used in WithShortcodeParams
+const optShortcodeParams renderer.OptionName = "ShortcodeParams"
+
+type withShortcodeParams struct {
+	value ShortcodeParams
+}
+
+func (o *withShortcodeParams) SetConfig(c *renderer.Config) {
+	c.Options[optShortcodeParams] = o.value
+}
+
+// WithShortcodeParams is a functional option that resolves the links of the given shortcode
+// parameters in text and HTML blocks with the ResolveLink of the renderer
+func WithShortcodeParams(params ShortcodeParams) renderer.Option {
+	return &withShortcodeParams{params}
+}
+
+// ContentLinkResolver is an option name used in WithContentLinkResolver
+const optContentLinkResolver renderer.OptionName = "ContentLinkResolver"
+
+type withContentLinkResolver struct {
+	value ResolveLink
+}
+
+func (o *withContentLinkResolver) SetConfig(c *renderer.Config) {
+	c.Options[optContentLinkResolver] = o.value
+}
+
+// WithContentLinkResolver is a functional option that resolves the links of the Hugo `ref` and `relref`
+// shortcodes, which refer to content paths, e.g. `/concepts/apiserver.md`. They are resolved with the
+// ResolveLink of the renderer if not set
+func WithContentLinkResolver(contentLinkResolver ResolveLink) renderer.Option {
+	return &withContentLinkResolver{contentLinkResolver}
+}
+
+// mergeShortcodeText extends a text node with the contiguous text nodes of the same line
+// until its last shortcode call is closed, as the parser may split a call in several nodes
+func mergeShortcodeText(n *ast.Text, source []byte) {
+	for !n.SoftLineBreak() && !n.HardLineBreak() && unclosedShortcode(n.Segment.Value(source)) {
+		next, ok := n.NextSibling().(*ast.Text)
+		if !ok || next.Segment.Start != n.Segment.Stop {
+			return
+		}
+		n.Segment = n.Segment.WithStop(next.Segment.Stop)
+		n.SetSoftLineBreak(next.SoftLineBreak())
+		n.SetHardLineBreak(next.HardLineBreak())
+		n.Parent().RemoveChild(n.Parent(), next)
+	}
+}
+
+func unclosedShortcode(txt []byte) bool {
+	open := max(bytes.LastIndex(txt, []byte("{{<")), bytes.LastIndex(txt, []byte("{{%")))
+	closed := max(bytes.LastIndex(txt, []byte(">}}")), bytes.LastIndex(txt, []byte("%}}")))
+	return open > closed
+}
+
+// modifyShortcodes resolves the links of the configured shortcode parameters
+func (r *Renderer) modifyShortcodes(source []byte) ([]byte, bool, error) {
+	if len(r.shortcodes) == 0 || !bytes.Contains(source, []byte("{{")) {
+		return source, false, nil
+	}
+	var b bytes.Buffer
+	modified := false
+	rest := source
+	for {
+		start := bytes.Index(rest, []byte("{{"))
+		if start < 0 || start+2 >= len(rest) {
+			break
+		}
+		delimiter := rest[start+2]
+		if delimiter != '<' && delimiter != '%' {
+			b.Write(rest[:start+2])
+			rest = rest[start+2:]
+			continue
+		}
+		closing := []byte("%}}")
+		if delimiter == '<' {
+			closing = []byte(">}}")
+		}
+		end := bytes.Index(rest[start:], closing)
+		if end < 0 {
+			break
+		}
+		end += start
+		b.Write(rest[:start+3])
+		call, m, err := r.modifyShortcodeCall(rest[start+3 : end])
+		if err != nil {
+			return source, false, err
+		}
+		modified = modified || m
+		b.Write(call)
+		rest = rest[end:]
+	}
+	b.Write(rest)
+	if !modified {
+		return source, false, nil
+	}
+	return b.Bytes(), true, nil
+}
+
+// modifyShortcodeCall resolves the links of a shortcode call between its delimiters, e.g. ` figure src="img.png" `
+func (r *Renderer) modifyShortcodeCall(call []byte) ([]byte, bool, error) {
+	fields := bytes.Fields(call)
+	if len(fields) == 0 || bytes.HasPrefix(fields[0], []byte("/")) {
+		return call, false, nil
+	}
+	nameStart := bytes.Index(call, fields[0])
+	name := string(fields[0])
+	if _, ok := r.shortcodes[name]; !ok {
+		return call, false, nil
+	}
+	argsStart := nameStart + len(fields[0])
+	var b bytes.Buffer
+	b.Write(call[:argsStart])
+	modified := false
+	last, position := argsStart, 0
+	for _, m := range shortcodeArgRgx.FindAllSubmatchIndex(call[argsStart:], -1) {
+		param := ""
+		if m[2] >= 0 {
+			param = string(call[argsStart+m[2] : argsStart+m[3]])
+		}
+		valueStart, valueStop := argsStart+m[4], argsStart+m[5]
+		isLink := r.shortcodes.isLink(name, param, position)
+		if param == "" {
+			position++
+		}
+		if !isLink {
+			continue
+		}
+		value := call[valueStart:valueStop]
+		quote := ""
+		if value[0] == '"' || value[0] == '`' {
+			quote = string(value[0])
+			value = value[1 : len(value)-1]
+		}
+		resolve := r.linkResolver
+		if r.contentLinkResolver != nil && slices.Contains(contentLinkShortcodes, name) {
+			resolve = r.contentLinkResolver
+		}
+		dest, err := resolve(string(value), param == "src")
+		if err != nil {
+			return call, false, err
+		}
+		if dest == string(value) {
+			continue
+		}
+		if quote == "" {
+			quote = `"`
+		}
+		b.Write(call[last:valueStart])
+		b.WriteString(quote + dest + quote)
+		last = valueStop
+		modified = true
+	}
+	b.Write(call[last:])
+	return b.Bytes(), modified, nil
+}
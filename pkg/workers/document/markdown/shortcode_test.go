@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package markdown_test
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/gardener/docforge/pkg/workers/document/markdown"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Shortcodes", func() {
+	var (
+		resolved   map[string]bool
+		shortcodes markdown.ShortcodeParams
+	)
+	resolve := func(dest string, isEmbeddable bool) (string, error) {
+		resolved[dest] = isEmbeddable
+		if strings.HasPrefix(dest, "http") {
+			return dest, nil
+		}
+		return "/resolved/" + strings.TrimLeft(dest, "./"), nil
+	}
+	BeforeEach(func() {
+		var err error
+		resolved = map[string]bool{}
+		shortcodes, err = markdown.ParseShortcodeParams(markdown.DefaultShortcodeLinks)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	DescribeTable("rewriting shortcode links",
+		func(md string, expected string) {
+			doc, err := markdown.Parse([]byte(md))
+			Expect(err).NotTo(HaveOccurred())
+			buf := &bytes.Buffer{}
+			rnd := markdown.NewLinkModifierRenderer(markdown.WithLinkResolver(resolve), markdown.WithShortcodeParams(shortcodes))
+			Expect(rnd.Render(buf, []byte(md), doc)).To(Succeed())
+			Expect(buf.String()).To(Equal(expected))
+		},
+		Entry("positional parameter", "See {{< ref \"../foo.md\" >}} and {{% relref \"bar.md#setup\" %}}.\n",
+			"See {{< ref \"/resolved/foo.md\" >}} and {{% relref \"/resolved/bar.md#setup\" %}}.\n"),
+		Entry("named parameters", "{{< figure src=\"./img_one.png\" link=https://example.com width=\"50%\" caption=\"One image\" >}}\n",
+			"{{< figure src=\"/resolved/img_one.png\" link=https://example.com width=\"50%\" caption=\"One image\" >}}\n"),
+		Entry("unknown shortcode", "{{< youtube \"w7Ft2ymGmfc\" >}}\n", "{{< youtube \"w7Ft2ymGmfc\" >}}\n"),
+		Entry("HTML block", "<div>\n<a href=\"{{< ref \"foo.md\" >}}\">Foo</a>\n</div>\n",
+			"<div>\n<a href=\"{{< ref \"/resolved/foo.md\" >}}\">Foo</a>\n</div>\n"),
+		Entry("code span", "Write `{{< ref \"foo.md\" >}}`.\n", "Write `{{< ref \"foo.md\" >}}`.\n"),
+	)
+
+	It("resolves src parameters as embeddable", func() {
+		md := "{{< figure src=\"img.png\" link=\"page.md\" >}}\n"
+		doc, err := markdown.Parse([]byte(md))
+		Expect(err).NotTo(HaveOccurred())
+		rnd := markdown.NewLinkModifierRenderer(markdown.WithLinkResolver(resolve), markdown.WithShortcodeParams(shortcodes))
+		Expect(rnd.Render(&bytes.Buffer{}, []byte(md), doc)).To(Succeed())
+		Expect(resolved).To(Equal(map[string]bool{"img.png": true, "page.md": false}))
+	})
+
+	It("resolves ref and relref links with the content link resolver", func() {
+		md := "{{< ref \"foo.md\" >}} {{< relref \"bar.md\" >}} {{< figure src=\"img.png\" link=\"page.md\" >}}\n"
+		doc, err := markdown.Parse([]byte(md))
+		Expect(err).NotTo(HaveOccurred())
+		content := func(dest string, _ bool) (string, error) {
+			return "/content/" + dest, nil
+		}
+		buf := &bytes.Buffer{}
+		rnd := markdown.NewLinkModifierRenderer(markdown.WithLinkResolver(resolve), markdown.WithContentLinkResolver(content), markdown.WithShortcodeParams(shortcodes))
+		Expect(rnd.Render(buf, []byte(md), doc)).To(Succeed())
+		Expect(buf.String()).To(Equal("{{< ref \"/content/foo.md\" >}} {{< relref \"/content/bar.md\" >}} {{< figure src=\"/resolved/img.png\" link=\"/resolved/page.md\" >}}\n"))
+	})
+
+	It("fails for invalid parameters", func() {
+		_, err := markdown.ParseShortcodeParams([]string{"figure"})
+		Expect(err).To(MatchError("invalid shortcode parameter figure, expected shortcode:parameter"))
+	})
+})
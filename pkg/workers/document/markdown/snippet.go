@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yuin/goldmark/renderer"
+)
+
+var (
+	// defines the attributes of a fenced code block, e.g. ```yaml {source="./shoot.yaml" lines="5-30"}
+	attributesRgx = regexp.MustCompile(`\{([^}]*)\}\s*$`)
+	attributeRgx  = regexp.MustCompile(`([\w-]+)\s*=\s*"([^"]*)"`)
+	// defines the region markers in embedded files, e.g. # docforge:region foo
+	codeRegionRgx = regexp.MustCompile(`docforge:(region|endregion)\s+(\S+)`)
+)
+
+// Snippet is a code snippet embedded in a fenced code block from a repository file
+type Snippet struct {
+	// Source is the link to the embedded file
+	Source string
+	// Lines is the embedded line range, e.g. `5-30`, `5-` or `5`
+	Lines string
+	// Region is the name of the embedded region, marked with `docforge:region <name>`
+	// and `docforge:endregion <name>` in the file
+	Region string
+}
+
+// ParseSnippet returns the snippet defined by the attributes of a fenced code block info
+func ParseSnippet(info []byte) (Snippet, bool) {
+	m := attributesRgx.FindSubmatch(info)
+	if m == nil {
+		return Snippet{}, false
+	}
+	var s Snippet
+	for _, attr := range attributeRgx.FindAllSubmatch(m[1], -1) {
+		switch string(attr[1]) {
+		case "source":
+			s.Source = string(attr[2])
+		case "lines":
+			s.Lines = string(attr[2])
+		case "region":
+			s.Region = string(attr[2])
+		}
+	}
+	return s, s.Source != ""
+}
+
+// SelectSnippet returns the lines or the region of a file content defined by the snippet.
+// The region markers are not part of the result
+func SelectSnippet(content []byte, s Snippet) ([]byte, error) {
+	lines := bytes.SplitAfter(content, []byte("\n"))
+	if len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	if s.Lines != "" {
+		from, to, err := lineRange(s.Lines, len(lines))
+		if err != nil {
+			return nil, err
+		}
+		lines = lines[from-1 : to]
+	}
+	if s.Region != "" {
+		start, end := -1, -1
+		for i, l := range lines {
+			m := codeRegionRgx.FindSubmatch(l)
+			if m == nil || string(m[2]) != s.Region {
+				continue
+			}
+			if string(m[1]) == "region" && start < 0 {
+				start = i + 1
+			} else if string(m[1]) == "endregion" && start >= 0 {
+				end = i
+				break
+			}
+		}
+		if start < 0 || end < 0 {
+			return nil, fmt.Errorf("region %s not found", s.Region)
+		}
+		lines = lines[start:end]
+	}
+	var b bytes.Buffer
+	for _, l := range lines {
+		// markers of nested regions are dropped
+		if !codeRegionRgx.Match(l) {
+			b.Write(l)
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// lineRange returns the first and the last line of a range of 1-based lines
+func lineRange(lines string, count int) (int, int, error) {
+	fromStr, toStr, isRange := strings.Cut(lines, "-")
+	from, err := strconv.Atoi(strings.TrimSpace(fromStr))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid lines %s", lines)
+	}
+	to := from
+	if isRange {
+		to = count
+		if strings.TrimSpace(toStr) != "" {
+			if to, err = strconv.Atoi(strings.TrimSpace(toStr)); err != nil {
+				return 0, 0, fmt.Errorf("invalid lines %s", lines)
+			}
+		}
+	}
+	if from < 1 || from > to || to > count {
+		return 0, 0, fmt.Errorf("lines %s out of range, the file has %d lines", lines, count)
+	}
+	return from, to, nil
+}
+
+// ResolveSnippet type defines function returning the content of an embedded code snippet
+type ResolveSnippet func(snippet Snippet) ([]byte, error)
+
+// SnippetResolver is an option name used in WithSnippetResolver.
+const optSnippetResolver renderer.OptionName = "SnippetResolver"
+
+type withSnippetResolver struct {
+	value ResolveSnippet
+}
+
+func (o *withSnippetResolver) SetConfig(c *renderer.Config) {
+	c.Options[optSnippetResolver] = o.value
+}
+
+// WithSnippetResolver is a functional option that fills the fenced code blocks defining a snippet
+// with its content. Without it the code blocks are rendered as they are
+func WithSnippetResolver(snippetResolver ResolveSnippet) renderer.Option {
+	return &withSnippetResolver{snippetResolver}
+}
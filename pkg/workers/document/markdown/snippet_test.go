@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package markdown_test
+
+import (
+	"bytes"
+
+	"github.com/gardener/docforge/pkg/workers/document/markdown"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Snippets", func() {
+	const file = "package main\n\n// docforge:region main\nfunc main() {\n\t// docforge:region print\n\tprintln()\n\t// docforge:endregion print\n}\n// docforge:endregion main\n"
+
+	DescribeTable("parsing fenced code block attributes",
+		func(info string, expected markdown.Snippet, ok bool) {
+			snippet, found := markdown.ParseSnippet([]byte(info))
+			Expect(found).To(Equal(ok))
+			Expect(snippet).To(Equal(expected))
+		},
+		Entry("source and lines", `yaml {source="../example/shoot.yaml" lines="5-30"}`, markdown.Snippet{Source: "../example/shoot.yaml", Lines: "5-30"}, true),
+		Entry("source and region", `go {source="main.go" region="main"}`, markdown.Snippet{Source: "main.go", Region: "main"}, true),
+		Entry("no source", `go {linenos=true}`, markdown.Snippet{}, false),
+		Entry("no attributes", `go`, markdown.Snippet{}, false),
+	)
+
+	DescribeTable("selecting snippets",
+		func(snippet markdown.Snippet, expected string) {
+			content, err := markdown.SelectSnippet([]byte(file), snippet)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal(expected))
+		},
+		Entry("whole file without markers", markdown.Snippet{Source: "main.go"}, "package main\n\nfunc main() {\n\tprintln()\n}\n"),
+		Entry("line range", markdown.Snippet{Source: "main.go", Lines: "4-6"}, "func main() {\n\tprintln()\n"),
+		Entry("open line range", markdown.Snippet{Source: "main.go", Lines: "8-"}, "}\n"),
+		Entry("single line", markdown.Snippet{Source: "main.go", Lines: "1"}, "package main\n"),
+		Entry("region", markdown.Snippet{Source: "main.go", Region: "main"}, "func main() {\n\tprintln()\n}\n"),
+		Entry("nested region", markdown.Snippet{Source: "main.go", Region: "print"}, "\tprintln()\n"),
+	)
+
+	DescribeTable("failing to select snippets",
+		func(snippet markdown.Snippet, expected string) {
+			_, err := markdown.SelectSnippet([]byte(file), snippet)
+			Expect(err).To(MatchError(expected))
+		},
+		Entry("missing region", markdown.Snippet{Source: "main.go", Region: "test"}, "region test not found"),
+		Entry("lines out of range", markdown.Snippet{Source: "main.go", Lines: "5-20"}, "lines 5-20 out of range, the file has 9 lines"),
+		Entry("invalid lines", markdown.Snippet{Source: "main.go", Lines: "a-b"}, "invalid lines a-b"),
+	)
+
+	It("renders code blocks with the snippets", func() {
+		md := "```go {source=\"main.go\" region=\"print\"}\n```\n\n```go\nfmt.Println()\n```\n"
+		doc, err := markdown.Parse([]byte(md))
+		Expect(err).NotTo(HaveOccurred())
+		var requested []markdown.Snippet
+		resolve := func(snippet markdown.Snippet) ([]byte, error) {
+			requested = append(requested, snippet)
+			return markdown.SelectSnippet([]byte(file), snippet)
+		}
+		buf := &bytes.Buffer{}
+		Expect(markdown.NewLinkModifierRenderer(markdown.WithSnippetResolver(resolve)).Render(buf, []byte(md), doc)).To(Succeed())
+		Expect(buf.String()).To(Equal("```go\n\tprintln()\n```\n\n```go\nfmt.Println()\n```\n"))
+		Expect(requested).To(Equal([]markdown.Snippet{{Source: "main.go", Region: "print"}}))
+	})
+})
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+var (
+	tocMarkerRgx = regexp.MustCompile(`^<!--\s*toc\s*-->\s*$`)
+	tocEscaper   = strings.NewReplacer(`\`, `\\`, `[`, `\[`, `]`, `\]`)
+)
+
+// TOCEntry is a heading listed in a table of contents
+type TOCEntry struct {
+	// Level is the rendered heading level
+	Level int
+	// Text is the heading text
+	Text string
+	// ID is the heading anchor
+	ID string
+}
+
+// NewTOC returns a list of links to the entries nested by level or nil if there are no entries.
+// The list does not refer to a source, so it can be inserted in any document
+func NewTOC(entries []TOCEntry) ast.Node {
+	if len(entries) == 0 {
+		return nil
+	}
+	var b bytes.Buffer
+	var levels []int
+	for _, e := range entries {
+		// nest under the closest previous entry of a lower level
+		for len(levels) > 0 && levels[len(levels)-1] >= e.Level {
+			levels = levels[:len(levels)-1]
+		}
+		b.WriteString(strings.Repeat("  ", len(levels)))
+		b.WriteString("- [" + tocEscaper.Replace(e.Text) + "](#" + e.ID + ")\n")
+		levels = append(levels, e.Level)
+	}
+	source := b.Bytes()
+	doc, _ := Parse(source)
+	list := doc.FirstChild()
+	if list == nil {
+		return nil
+	}
+	var texts []*ast.Text
+	_ = ast.Walk(list, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		if t, ok := n.(*ast.Text); ok {
+			texts = append(texts, t)
+		} else if n.Type() == ast.TypeBlock {
+			n.SetLines(text.NewSegments())
+		}
+		return ast.WalkContinue, nil
+	})
+	for _, t := range texts {
+		t.Parent().ReplaceChild(t.Parent(), t, ast.NewString(t.Segment.Value(source)))
+	}
+	doc.RemoveChild(doc, list)
+	list.SetBlankPreviousLines(true)
+	return list
+}
+
+// TOCMarker returns the top-level `<!-- toc -->` block of a document or nil
+func TOCMarker(doc ast.Node, source []byte) ast.Node {
+	for c := doc.FirstChild(); c != nil; c = c.NextSibling() {
+		h, ok := c.(*ast.HTMLBlock)
+		if !ok || h.Lines().Len() != 1 {
+			continue
+		}
+		segment := h.Lines().At(0)
+		if tocMarkerRgx.Match(segment.Value(source)) {
+			return h
+		}
+	}
+	return nil
+}
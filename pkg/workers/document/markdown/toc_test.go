@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package markdown_test
+
+import (
+	"bytes"
+
+	"github.com/gardener/docforge/pkg/workers/document/markdown"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TOC", func() {
+	It("replaces the marker with the nested list of headings", func() {
+		md := "# Title\n\n<!-- toc -->\n\nText\n"
+		doc, err := markdown.Parse([]byte(md))
+		Expect(err).NotTo(HaveOccurred())
+		marker := markdown.TOCMarker(doc, []byte(md))
+		Expect(marker).NotTo(BeNil())
+		toc := markdown.NewTOC([]markdown.TOCEntry{
+			{Level: 3, Text: "Before", ID: "before"},
+			{Level: 2, Text: "Install", ID: "install"},
+			{Level: 3, Text: "Linux", ID: "linux"},
+			{Level: 4, Text: "Debian", ID: "debian"},
+			{Level: 2, Text: "Use [it]", ID: "use-it"},
+		})
+		doc.ReplaceChild(doc, marker, toc)
+		buf := &bytes.Buffer{}
+		Expect(markdown.NewLinkModifierRenderer().Render(buf, []byte(md), doc)).To(Succeed())
+		Expect(buf.String()).To(Equal("# Title\n\n- [Before](#before)\n- [Install](#install)\n  - [Linux](#linux)\n    - [Debian](#debian)\n- [Use \\[it\\]](#use-it)\n\nText\n"))
+	})
+
+	It("returns no list without entries", func() {
+		Expect(markdown.NewTOC(nil)).To(BeNil())
+	})
+
+	It("returns no marker in code blocks", func() {
+		md := "```\n<!-- toc -->\n```\n"
+		doc, err := markdown.Parse([]byte(md))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(markdown.TOCMarker(doc, []byte(md))).To(BeNil())
+	})
+})
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package document
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gardener/docforge/pkg/readers/repositoryhosts"
+	"github.com/gardener/docforge/pkg/workers/document/markdown"
+)
+
+// snippetResolver returns a markdown.ResolveSnippet reading the snippets embedded in the document docURI
+func (d *Worker) snippetResolver(ctx context.Context, docURI string) markdown.ResolveSnippet {
+	return func(snippet markdown.Snippet) ([]byte, error) {
+		repoHost, err := d.Repositoryhosts.Get(docURI)
+		if err != nil {
+			return nil, fmt.Errorf("fail to embed snippet %s in %s: %w", snippet.Source, docURI, err)
+		}
+		return readSnippet(ctx, repoHost, docURI, snippet)
+	}
+}
+
+// readSnippet reads a snippet relative to the document docURI, at the document ref, with the repository host of the document
+func readSnippet(ctx context.Context, repoHost repositoryhosts.RepositoryHost, docURI string, snippet markdown.Snippet) ([]byte, error) {
+	fileURI, err := repoHost.ToAbsLink(docURI, snippet.Source)
+	if err != nil {
+		return nil, fmt.Errorf("fail to resolve snippet %s in %s: %w", snippet.Source, docURI, err)
+	}
+	content, err := repoHost.Read(ctx, fileURI)
+	if err != nil {
+		return nil, fmt.Errorf("reading snippet %s in %s failed: %w", snippet.Source, docURI, err)
+	}
+	if content, err = markdown.SelectSnippet(content, snippet); err != nil {
+		return nil, fmt.Errorf("fail to embed snippet %s in %s: %w", snippet.Source, docURI, err)
+	}
+	return content, nil
+}
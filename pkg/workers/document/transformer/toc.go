@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"fmt"
+
+	"github.com/gardener/docforge/pkg/workers/document/markdown"
+	"github.com/yuin/goldmark/ast"
+)
+
+const (
+	// TOCName is the name of the table of contents transformer
+	TOCName = "toc"
+	// TOCMinLevel is the option with the level of the top listed headings
+	TOCMinLevel = "minLevel"
+	// TOCMaxLevel is the option with the level of the deepest listed headings
+	TOCMaxLevel = "maxLevel"
+)
+
+// TOC inserts the table of contents of the document at its `<!-- toc -->` marker, after the first
+// heading of its first content or at its top. The headings are listed with their anchors in the
+// concatenated document, from the `minLevel` option, 2 by default, to the `maxLevel` option, 3 by default
+func TOC(_ ast.Node, ctx *Context) error {
+	// the table of contents covers all contents
+	if ctx.Index > 0 {
+		return nil
+	}
+	minLevel, err := levelOption(ctx.Options, TOCMinLevel, 2)
+	if err != nil {
+		return err
+	}
+	maxLevel, err := levelOption(ctx.Options, TOCMaxLevel, 3)
+	if err != nil {
+		return err
+	}
+	if minLevel > maxLevel {
+		return fmt.Errorf("%s %d is greater than %s %d", TOCMinLevel, minLevel, TOCMaxLevel, maxLevel)
+	}
+	var entries []markdown.TOCEntry
+	ids := map[string]int{}
+	for _, c := range ctx.Contents {
+		for _, h := range markdown.Headings(c.Doc, c.Source, ids) {
+			level := min(h.Node.Level+c.HeadingOffset, 6)
+			if level >= minLevel && level <= maxLevel {
+				entries = append(entries, markdown.TOCEntry{Level: level, Text: markdown.HeadingText(h.Node, c.Source), ID: h.ID})
+			}
+		}
+	}
+	list := markdown.NewTOC(entries)
+	if list == nil {
+		return nil
+	}
+	for _, c := range ctx.Contents {
+		if marker := markdown.TOCMarker(c.Doc, c.Source); marker != nil {
+			c.Doc.ReplaceChild(c.Doc, marker, list)
+			return nil
+		}
+	}
+	doc := ctx.Contents[0].Doc
+	switch heading := markdown.FirstHeading(doc); {
+	case heading != nil:
+		doc.InsertAfter(doc, heading, list)
+	case doc.FirstChild() != nil:
+		doc.InsertBefore(doc, doc.FirstChild(), list)
+	default:
+		doc.AppendChild(doc, list)
+	}
+	if next := list.NextSibling(); next != nil {
+		next.SetBlankPreviousLines(true)
+	}
+	return nil
+}
+
+// levelOption returns the heading level of an option or def if it is not set
+func levelOption(options map[string]interface{}, name string, def int) (int, error) {
+	value, ok := options[name]
+	if !ok {
+		return def, nil
+	}
+	level, ok := value.(int)
+	if !ok || level < 1 || level > 6 {
+		return 0, fmt.Errorf("option %s must be a heading level from 1 to 6, got %v", name, value)
+	}
+	return level, nil
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer_test
+
+import (
+	"bytes"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/workers/document/markdown"
+	"github.com/gardener/docforge/pkg/workers/document/transformer"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TOC", func() {
+	var (
+		err      error
+		sources  []string
+		contents []*transformer.Content
+		config   interface{}
+	)
+
+	BeforeEach(func() {
+		sources = []string{"## Overview\n\nIntro.\n\n### Details\n\n#### Deep\n"}
+		config = true
+	})
+
+	JustBeforeEach(func() {
+		contents = nil
+		for _, source := range sources {
+			doc, parseErr := markdown.Parse([]byte(source))
+			Expect(parseErr).NotTo(HaveOccurred())
+			contents = append(contents, &transformer.Content{Doc: doc, Source: []byte(source), SourceURI: "https://github.com/gardener/docforge/blob/master/doc.md"})
+		}
+		node := &manifest.Node{FileType: manifest.FileType{File: "doc.md"}, Type: "file", Transformers: map[string]interface{}{transformer.TOCName: config}}
+		err = transformer.DefaultRegistry.Transform(contents, &transformer.Context{Node: node})
+	})
+
+	render := func(c *transformer.Content) string {
+		var b bytes.Buffer
+		Expect(markdown.NewLinkModifierRenderer().Render(&b, c.Source, c.Doc)).To(Succeed())
+		return b.String()
+	}
+
+	It("inserts the table of contents after the first heading", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(render(contents[0])).To(Equal("## Overview\n\n- [Overview](#overview)\n  - [Details](#details)\n\nIntro.\n\n### Details\n\n#### Deep\n"))
+	})
+
+	When("the document has no heading", func() {
+		BeforeEach(func() {
+			sources = []string{"Intro.\n", "## Details\n"}
+		})
+
+		It("inserts the table of contents at the top", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(render(contents[0])).To(Equal("- [Details](#details)\n\nIntro.\n"))
+			Expect(render(contents[1])).To(Equal("## Details\n"))
+		})
+	})
+
+	When("a content has a marker", func() {
+		BeforeEach(func() {
+			sources = append(sources, "<!-- toc -->\n\n## Details\n")
+		})
+
+		It("replaces the marker and lists the headings of all contents", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(render(contents[0])).To(Equal(sources[0]))
+			Expect(render(contents[1])).To(Equal("- [Overview](#overview)\n  - [Details](#details)\n- [Details](#details-1)\n\n## Details\n"))
+		})
+	})
+
+	When("levels are configured", func() {
+		BeforeEach(func() {
+			config = map[string]interface{}{transformer.TOCMinLevel: 3, transformer.TOCMaxLevel: 4}
+		})
+
+		It("lists the headings of the levels", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(render(contents[0])).To(HavePrefix("## Overview\n\n- [Details](#details)\n  - [Deep](#deep)\n\n"))
+		})
+	})
+
+	DescribeTable("invalid levels",
+		func(options map[string]interface{}, expected string) {
+			node := &manifest.Node{FileType: manifest.FileType{File: "doc.md"}, Type: "file", Transformers: map[string]interface{}{transformer.TOCName: options}}
+			doc, err := markdown.Parse([]byte("# Title\n"))
+			Expect(err).NotTo(HaveOccurred())
+			contents := []*transformer.Content{{Doc: doc, Source: []byte("# Title\n"), SourceURI: "doc.md"}}
+			Expect(transformer.DefaultRegistry.Transform(contents, &transformer.Context{Node: node})).To(MatchError("transformer toc failed on doc.md: " + expected))
+		},
+		Entry("out of range", map[string]interface{}{"maxLevel": 7}, "option maxLevel must be a heading level from 1 to 6, got 7"),
+		Entry("not a number", map[string]interface{}{"minLevel": "2"}, "option minLevel must be a heading level from 1 to 6, got 2"),
+		Entry("inverted", map[string]interface{}{"minLevel": 4, "maxLevel": 2}, "minLevel 4 is greater than maxLevel 2"),
+	)
+})
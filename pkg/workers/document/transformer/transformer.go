@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/gardener/docforge/cmd/hugo"
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/yuin/goldmark/ast"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate -header ../../../../license_prefix.txt
+
+// Transformer rewrites the AST of a document before it is rendered
+//
+//counterfeiter:generate . Transformer
+type Transformer interface {
+	Transform(doc ast.Node, ctx *Context) error
+}
+
+// Func adapts a function to a Transformer
+type Func func(doc ast.Node, ctx *Context) error
+
+// Transform calls f(doc, ctx)
+func (f Func) Transform(doc ast.Node, ctx *Context) error {
+	return f(doc, ctx)
+}
+
+// Content is a source of a document
+type Content struct {
+	// Doc is the AST of the source
+	Doc ast.Node
+	// Source is the markdown content the AST segments refer to
+	Source []byte
+	// SourceURI is the URL of the source
+	SourceURI string
+	// HeadingOffset demotes the rendered headings of the source
+	HeadingOffset int
+}
+
+// Context is the metadata of a transformed document
+type Context struct {
+	// Node is the manifest node of the document
+	Node *manifest.Node
+	// Contents are the sources of the document in order
+	Contents []*Content
+	// Index is the position of the transformed source in Contents
+	Index int
+	// Source is the markdown content the AST segments refer to
+	Source []byte
+	// SourceURI is the URL of the document source
+	SourceURI string
+	// Frontmatter is the document front matter, merged with the node front matter
+	Frontmatter map[string]interface{}
+	// Hugo is the hugo configuration
+	Hugo hugo.Hugo
+	// Options are the transformer options set by the node `transformers` configuration
+	Options map[string]interface{}
+}
+
+type entry struct {
+	name        string
+	transformer Transformer
+	enabled     bool
+}
+
+// Registry runs transformers in registration order
+type Registry struct {
+	mux     sync.RWMutex
+	entries []entry
+}
+
+// DefaultRegistry is the registry of the document worker with the built-in transformers
+var DefaultRegistry = newDefaultRegistry()
+
+func newDefaultRegistry() *Registry {
+	r := NewRegistry()
+	_ = r.Register(TOCName, Func(TOC), false)
+	return r
+}
+
+// NewRegistry creates an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds a named transformer to the registry. Transformers not enabled by default run only
+// for the nodes that enable them in their `transformers` configuration
+func (r *Registry) Register(name string, t Transformer, enabled bool) error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	for _, e := range r.entries {
+		if e.name == name {
+			return fmt.Errorf("transformer %s is already registered", name)
+		}
+	}
+	r.entries = append(r.entries, entry{name: name, transformer: t, enabled: enabled})
+	return nil
+}
+
+// Register adds a named transformer to the DefaultRegistry
+func Register(name string, t Transformer, enabled bool) error {
+	return DefaultRegistry.Register(name, t, enabled)
+}
+
+// Names returns the names of the registered transformers in order
+func (r *Registry) Names() []string {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	names := make([]string, 0, len(r.entries))
+	for _, e := range r.entries {
+		names = append(names, e.name)
+	}
+	return names
+}
+
+// Transform runs the transformers enabled for the context node on the document contents. Each transformer
+// runs on all contents before the next one. The node `transformers` configuration maps names to `true`
+// or `false` to enable or disable a transformer, or to its options
+func (r *Registry) Transform(contents []*Content, ctx *Context) error {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	var config map[string]interface{}
+	if ctx.Node != nil {
+		config = ctx.Node.Transformers
+	}
+	for name := range config {
+		if !r.registered(name) {
+			return fmt.Errorf("unknown transformer %s configured for node %s", name, ctx.Node.NodePath())
+		}
+	}
+	ctx.Contents = contents
+	for _, e := range r.entries {
+		enabled, options, err := parseConfig(e.name, config[e.name], e.enabled)
+		if err != nil {
+			return err
+		}
+		if !enabled {
+			continue
+		}
+		ctx.Options = options
+		for i, c := range contents {
+			ctx.Index, ctx.Source, ctx.SourceURI = i, c.Source, c.SourceURI
+			if err = e.transformer.Transform(c.Doc, ctx); err != nil {
+				return fmt.Errorf("transformer %s failed on %s: %w", e.name, c.SourceURI, err)
+			}
+		}
+	}
+	ctx.Options = nil
+	return nil
+}
+
+func (r *Registry) registered(name string) bool {
+	for _, e := range r.entries {
+		if e.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// parseConfig returns whether a transformer is enabled and its options
+func parseConfig(name string, config interface{}, enabled bool) (bool, map[string]interface{}, error) {
+	switch c := config.(type) {
+	case nil:
+		return enabled, map[string]interface{}{}, nil
+	case bool:
+		return c, map[string]interface{}{}, nil
+	case map[string]interface{}:
+		return true, c, nil
+	case map[interface{}]interface{}:
+		options := make(map[string]interface{}, len(c))
+		for k, v := range c {
+			options[fmt.Sprint(k)] = v
+		}
+		return true, options, nil
+	default:
+		return false, nil, fmt.Errorf("invalid configuration of transformer %s: expected a boolean or options, got %T", name, config)
+	}
+}
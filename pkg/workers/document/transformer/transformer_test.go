@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/workers/document/transformer"
+	"github.com/gardener/docforge/pkg/workers/document/transformer/transformerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/yuin/goldmark/ast"
+)
+
+func TestTransformer(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Transformer Suite")
+}
+
+var _ = Describe("Registry", func() {
+	var (
+		err      error
+		registry *transformer.Registry
+		first    *transformerfakes.FakeTransformer
+		second   *transformerfakes.FakeTransformer
+		optIn    *transformerfakes.FakeTransformer
+		doc      ast.Node
+		contents []*transformer.Content
+		ctx      *transformer.Context
+		order    []string
+	)
+
+	BeforeEach(func() {
+		order = nil
+		registry = transformer.NewRegistry()
+		first = &transformerfakes.FakeTransformer{}
+		first.TransformCalls(func(_ ast.Node, ctx *transformer.Context) error {
+			order = append(order, fmt.Sprintf("first %d", ctx.Index))
+			return nil
+		})
+		second = &transformerfakes.FakeTransformer{}
+		second.TransformCalls(func(_ ast.Node, ctx *transformer.Context) error {
+			order = append(order, fmt.Sprintf("second %d", ctx.Index))
+			return nil
+		})
+		optIn = &transformerfakes.FakeTransformer{}
+		Expect(registry.Register("first", first, true)).To(Succeed())
+		Expect(registry.Register("second", second, true)).To(Succeed())
+		Expect(registry.Register("optIn", optIn, false)).To(Succeed())
+		doc = ast.NewDocument()
+		contents = []*transformer.Content{{Doc: doc, SourceURI: "https://github.com/gardener/docforge/blob/master/doc.md"}}
+		ctx = &transformer.Context{Node: &manifest.Node{FileType: manifest.FileType{File: "doc.md"}, Type: "file"}}
+	})
+
+	JustBeforeEach(func() {
+		err = registry.Transform(contents, ctx)
+	})
+
+	It("runs the enabled transformers in registration order", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(order).To(Equal([]string{"first 0", "second 0"}))
+		Expect(optIn.TransformCallCount()).To(Equal(0))
+		argDoc, argCtx := first.TransformArgsForCall(0)
+		Expect(argDoc).To(Equal(doc))
+		Expect(argCtx).To(Equal(ctx))
+		Expect(argCtx.Contents).To(Equal(contents))
+		Expect(argCtx.SourceURI).To(Equal("https://github.com/gardener/docforge/blob/master/doc.md"))
+		Expect(registry.Names()).To(Equal([]string{"first", "second", "optIn"}))
+	})
+
+	When("the document has several contents", func() {
+		BeforeEach(func() {
+			contents = append(contents, &transformer.Content{Doc: ast.NewDocument(), SourceURI: "https://github.com/gardener/docforge/blob/master/other.md"})
+		})
+
+		It("runs each transformer on all contents before the next one", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(order).To(Equal([]string{"first 0", "first 1", "second 0", "second 1"}))
+			argDoc, _ := first.TransformArgsForCall(1)
+			Expect(argDoc).To(Equal(contents[1].Doc))
+		})
+	})
+
+	It("rejects transformers registered twice", func() {
+		Expect(registry.Register("first", first, true)).To(MatchError("transformer first is already registered"))
+	})
+
+	When("the node configures transformers", func() {
+		var options map[string]interface{}
+
+		BeforeEach(func() {
+			ctx.Node.Transformers = map[string]interface{}{
+				"first": false,
+				"optIn": map[interface{}]interface{}{"depth": 2},
+			}
+			optIn.TransformCalls(func(_ ast.Node, ctx *transformer.Context) error {
+				options = ctx.Options
+				return nil
+			})
+		})
+
+		It("disables and enables transformers with options", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(first.TransformCallCount()).To(Equal(0))
+			Expect(second.TransformCallCount()).To(Equal(1))
+			Expect(optIn.TransformCallCount()).To(Equal(1))
+			Expect(options).To(Equal(map[string]interface{}{"depth": 2}))
+			Expect(ctx.Options).To(BeNil())
+		})
+	})
+
+	When("the node configures an unknown transformer", func() {
+		BeforeEach(func() {
+			ctx.Node.Transformers = map[string]interface{}{"unknown": true}
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError("unknown transformer unknown configured for node doc.md"))
+			Expect(first.TransformCallCount()).To(Equal(0))
+		})
+	})
+
+	When("the configuration is invalid", func() {
+		BeforeEach(func() {
+			ctx.Node.Transformers = map[string]interface{}{"second": "yes"}
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError("invalid configuration of transformer second: expected a boolean or options, got string"))
+		})
+	})
+
+	When("a transformer fails", func() {
+		BeforeEach(func() {
+			first.TransformCalls(nil)
+			first.TransformReturns(errors.New("boom"))
+		})
+
+		It("stops and returns the error", func() {
+			Expect(err).To(MatchError("transformer first failed on https://github.com/gardener/docforge/blob/master/doc.md: boom"))
+			Expect(second.TransformCallCount()).To(Equal(0))
+		})
+	})
+
+	It("adapts functions", func() {
+		called := false
+		f := transformer.Func(func(ast.Node, *transformer.Context) error {
+			called = true
+			return nil
+		})
+		Expect(f.Transform(doc, ctx)).To(Succeed())
+		Expect(called).To(BeTrue())
+	})
+})
@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package docusaurussidebars
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/workers/document/frontmatter"
+	"github.com/gardener/docforge/pkg/writers"
+)
+
+const (
+	// JS writes the sidebars as a CommonJS module
+	JS = "js"
+	// JSON writes the sidebars as a JSON document
+	JSON = "json"
+	// sidebarID is the id of the generated sidebar
+	sidebarID = "docs"
+)
+
+// Category is a sidebar category of a dir node
+type Category struct {
+	Type  string        `json:"type"`
+	Label string        `json:"label"`
+	Link  *CategoryLink `json:"link,omitempty"`
+	Items []interface{} `json:"items"`
+}
+
+// CategoryLink links a category to its index document
+type CategoryLink struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// Validate returns an error if the sidebars format is not supported
+func Validate(format string) error {
+	if format != JS && format != JSON {
+		return fmt.Errorf("unsupported sidebars format %s, must be one of: js or json", format)
+	}
+	return nil
+}
+
+// FileName returns the name of the sidebars file of a format
+func FileName(format string) string {
+	return "sidebars." + format
+}
+
+// Writer generates the Docusaurus sidebars from the structure. It decorates the writer of the
+// documents and records their front matter as written
+type Writer struct {
+	writer         writers.Writer
+	sidebarsWriter writers.Writer
+	format         string
+	indexFileNames []string
+
+	mux          sync.Mutex
+	frontmatters map[*manifest.Node]map[string]interface{}
+}
+
+// New creates a sidebars Writer decorating writer. The sidebars are written with sidebarsWriter in the format.
+// The documents matching indexFileNames are the category index documents of their dir nodes
+func New(writer writers.Writer, sidebarsWriter writers.Writer, format string, indexFileNames []string) (*Writer, error) {
+	if err := Validate(format); err != nil {
+		return nil, err
+	}
+	return &Writer{
+		writer:         writer,
+		sidebarsWriter: sidebarsWriter,
+		format:         format,
+		indexFileNames: indexFileNames,
+		frontmatters:   map[*manifest.Node]map[string]interface{}{},
+	}, nil
+}
+
+// Write records the written document front matter
+func (w *Writer) Write(name, path string, docBlob []byte, node *manifest.Node) error {
+	if node != nil && node.Type == "file" && len(docBlob) > 0 {
+		fm := frontmatter.Parse(docBlob)
+		if fm == nil {
+			fm = map[string]interface{}{}
+		}
+		w.mux.Lock()
+		w.frontmatters[node] = fm
+		w.mux.Unlock()
+	}
+	return w.writer.Write(name, path, docBlob, node)
+}
+
+// Generate writes the sidebars with the written documents in the structure
+func (w *Writer) Generate(root *manifest.Node) error {
+	items, _ := w.items(root, false)
+	if items == nil {
+		items = []interface{}{}
+	}
+	out, err := json.MarshalIndent(map[string]interface{}{sidebarID: items}, "", "  ")
+	if err != nil {
+		return err
+	}
+	var b bytes.Buffer
+	if w.format == JS {
+		b.WriteString("// @ts-check\n\n/** @type {import('@docusaurus/plugin-content-docs').SidebarsConfig} */\nmodule.exports = ")
+		b.Write(out)
+		b.WriteString(";\n")
+	} else {
+		b.Write(out)
+		b.WriteString("\n")
+	}
+	return w.sidebarsWriter.Write(FileName(w.format), "", b.Bytes(), nil)
+}
+
+// items returns the sidebar items of the written documents in a container node and the index document
+// of a section, which links its category instead of being an item
+func (w *Writer) items(container *manifest.Node, section bool) ([]interface{}, *manifest.Node) {
+	var items []interface{}
+	var index *manifest.Node
+	for _, child := range container.Structure {
+		switch child.Type {
+		case "dir":
+			if category := w.category(child); category != nil {
+				items = append(items, category)
+			}
+		case "file":
+			if _, written := w.frontmatters[child]; !written {
+				continue
+			}
+			if section && index == nil && w.isIndex(child) {
+				index = child
+				continue
+			}
+			items = append(items, w.docID(child))
+		}
+	}
+	return items, index
+}
+
+// category returns the sidebar category of a dir node or nil if it has no written documents
+func (w *Writer) category(dir *manifest.Node) *Category {
+	items, index := w.items(dir, true)
+	if len(items) == 0 && index == nil {
+		return nil
+	}
+	category := &Category{Type: "category", Label: frontmatter.NormalizeTitle(dir.Name()), Items: items}
+	if category.Items == nil {
+		category.Items = []interface{}{}
+	}
+	if index != nil {
+		category.Link = &CategoryLink{Type: "doc", ID: w.docID(index)}
+		if title, ok := w.frontmatters[index]["title"]; ok && title != nil {
+			category.Label = fmt.Sprint(title)
+		}
+	}
+	return category
+}
+
+// docID returns the Docusaurus id of a document, i.e. its folder and its `id` front matter or file name
+func (w *Writer) docID(node *manifest.Node) string {
+	id := strings.TrimSuffix(node.Name(), ".md")
+	if fmID, ok := w.frontmatters[node]["id"]; ok && fmID != nil {
+		id = fmt.Sprint(fmID)
+	}
+	return path.Clean(path.Join(node.Path, id))
+}
+
+// isIndex returns true if the node is an index file as defined by the section files
+func (w *Writer) isIndex(node *manifest.Node) bool {
+	for _, s := range w.indexFileNames {
+		if strings.EqualFold(node.Name(), s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package docusaurussidebars_test
+
+import (
+	"testing"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/workers/docusaurussidebars"
+	"github.com/gardener/docforge/pkg/writers/writersfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestDocusaurusSidebars(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Docusaurus Sidebars Suite")
+}
+
+var _ = Describe("Docusaurus sidebars", func() {
+	var (
+		err            error
+		writer         *writersfakes.FakeWriter
+		sidebarsWriter *writersfakes.FakeWriter
+		format         string
+		sut            *docusaurussidebars.Writer
+
+		root    *manifest.Node
+		intro   *manifest.Node
+		guides  *manifest.Node
+		readme  *manifest.Node
+		install *manifest.Node
+		setup   *manifest.Node
+		empty   *manifest.Node
+	)
+
+	BeforeEach(func() {
+		writer = &writersfakes.FakeWriter{}
+		sidebarsWriter = &writersfakes.FakeWriter{}
+		format = docusaurussidebars.JSON
+
+		intro = &manifest.Node{FileType: manifest.FileType{File: "intro.md"}, Type: "file", Path: "."}
+		install = &manifest.Node{FileType: manifest.FileType{File: "install.md"}, Type: "file", Path: "getting-started"}
+		readme = &manifest.Node{FileType: manifest.FileType{File: "README.md"}, Type: "file", Path: "getting-started"}
+		setup = &manifest.Node{FileType: manifest.FileType{File: "setup.md"}, Type: "file", Path: "getting-started"}
+		empty = &manifest.Node{DirType: manifest.DirType{Dir: "empty"}, Type: "dir", Path: "getting-started"}
+		guides = &manifest.Node{DirType: manifest.DirType{Dir: "getting-started", Structure: []*manifest.Node{install, readme, setup, empty}}, Type: "dir", Path: "."}
+		root = &manifest.Node{Type: "dir", DirType: manifest.DirType{Structure: []*manifest.Node{intro, guides}}}
+	})
+
+	JustBeforeEach(func() {
+		sut, err = docusaurussidebars.New(writer, sidebarsWriter, format, []string{"readme.md"})
+		if err != nil {
+			return
+		}
+		Expect(sut.Write(intro.Name(), intro.Path, []byte("# Intro\n"), intro)).To(Succeed())
+		Expect(sut.Write(install.Name(), install.Path, []byte("---\nid: installation\n---\n# Install\n"), install)).To(Succeed())
+		Expect(sut.Write(readme.Name(), readme.Path, []byte("---\ntitle: Getting Started\n---\n"), readme)).To(Succeed())
+		err = sut.Generate(root)
+	})
+
+	It("writes the documents with the decorated writer", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(writer.WriteCallCount()).To(Equal(3))
+	})
+
+	It("generates the sidebars of the written documents", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sidebarsWriter.WriteCallCount()).To(Equal(1))
+		name, path, content, node := sidebarsWriter.WriteArgsForCall(0)
+		Expect(name).To(Equal("sidebars.json"))
+		Expect(path).To(Equal(""))
+		Expect(node).To(BeNil())
+		Expect(string(content)).To(Equal(`{
+  "docs": [
+    "intro",
+    {
+      "type": "category",
+      "label": "Getting Started",
+      "link": {
+        "type": "doc",
+        "id": "getting-started/README"
+      },
+      "items": [
+        "getting-started/installation"
+      ]
+    }
+  ]
+}
+`))
+	})
+
+	Context("as JavaScript", func() {
+		BeforeEach(func() {
+			format = docusaurussidebars.JS
+		})
+
+		It("exports the sidebars", func() {
+			Expect(err).NotTo(HaveOccurred())
+			name, _, content, _ := sidebarsWriter.WriteArgsForCall(0)
+			Expect(name).To(Equal("sidebars.js"))
+			Expect(string(content)).To(HavePrefix("// @ts-check\n\n/** @type {import('@docusaurus/plugin-content-docs').SidebarsConfig} */\nmodule.exports = {\n  \"docs\": [\n"))
+			Expect(string(content)).To(HaveSuffix("\n};\n"))
+		})
+	})
+
+	Context("with an unsupported format", func() {
+		BeforeEach(func() {
+			format = "yaml"
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError("unsupported sidebars format yaml, must be one of: js or json"))
+		})
+	})
+})
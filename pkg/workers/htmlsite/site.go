@@ -0,0 +1,234 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package htmlsite
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	yamlfrontmatter "github.com/gardener/docforge/pkg/frontmatter"
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/workers/document/frontmatter"
+	"github.com/gardener/docforge/pkg/workers/linkresolver"
+	"github.com/gardener/docforge/pkg/writers"
+)
+
+const (
+	// IndexFileName is the name of the documents rendered as the index page of their folder
+	IndexFileName = "index.md"
+	// siteTitle is the title of the generated site index
+	siteTitle = "Documentation"
+)
+
+// DefaultTemplate is the page layout with the site navigation next to the page content
+const DefaultTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{ .Title }}</title>
+<style>
+body { display: flex; margin: 0; font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; line-height: 1.5; color: #24292f; }
+nav { flex: 0 0 16rem; min-height: 100vh; padding: 1rem; background: #f6f8fa; border-right: 1px solid #d0d7de; }
+nav ul { list-style: none; margin: 0; padding-left: 1rem; }
+nav a { color: inherit; text-decoration: none; }
+nav a.active { font-weight: bold; }
+main { flex: 1; max-width: 50rem; padding: 1rem 2rem; }
+pre { overflow: auto; padding: 1rem; background: #f6f8fa; }
+table { border-collapse: collapse; }
+th, td { padding: .25rem .75rem; border: 1px solid #d0d7de; }
+img { max-width: 100%; }
+</style>
+</head>
+<body>
+<nav>
+<a href="{{ .Root }}index.html">{{ .SiteTitle }}</a>
+{{ template "nav" .Nav }}
+</nav>
+<main>
+{{ .Content }}
+</main>
+</body>
+</html>
+{{ define "nav" }}{{ if . }}<ul>
+{{ range . }}<li>{{ if .URL }}<a href="{{ .URL }}"{{ if .Active }} class="active"{{ end }}>{{ .Title }}</a>{{ else }}{{ .Title }}{{ end }}{{ template "nav" .Children }}</li>
+{{ end }}</ul>{{ end }}{{ end }}
+`
+
+// Page is the data of the page layout template
+type Page struct {
+	// Path is the path of the page in the site, e.g. `operations/install.html`
+	Path string
+	// Title is the page title
+	Title string
+	// SiteTitle is the title of the site index
+	SiteTitle string
+	// Content is the page content rendered as HTML
+	Content template.HTML
+	// Root is the relative path from the page to the site root, e.g. `../`
+	Root string
+	// Nav is the site navigation with URLs relative to the page
+	Nav []*NavItem
+}
+
+// NavItem is an entry of the site navigation
+type NavItem struct {
+	// Title is the title of the page or folder
+	Title string
+	// URL is the page URL relative to the current page, empty for folders without an index page
+	URL string
+	// Active is true for the current page
+	Active bool
+	// Children are the entries of a folder in manifest order
+	Children []*NavItem
+}
+
+// document is a written HTML document
+type document struct {
+	content []byte
+	title   string
+}
+
+// Writer writes the documents rendered as HTML by the document worker as an HTML site. It decorates
+// the writer of the site pages and holds back the documents as written
+type Writer struct {
+	writer   writers.Writer
+	template *template.Template
+
+	mux  sync.Mutex
+	docs map[string][]byte
+}
+
+// New creates an HTML site Writer writing the pages with writer in the layout template
+func New(writer writers.Writer, layout string) (*Writer, error) {
+	tmpl, err := template.New("layout").Parse(layout)
+	if err != nil {
+		return nil, fmt.Errorf("invalid HTML template: %w", err)
+	}
+	return &Writer{
+		writer:   writer,
+		template: tmpl,
+		docs:     map[string][]byte{},
+	}, nil
+}
+
+// Write holds back the documents. Other content is written as it is
+func (w *Writer) Write(name, path string, docBlob []byte, node *manifest.Node) error {
+	if !strings.HasSuffix(name, ".md") {
+		return w.writer.Write(name, path, docBlob, node)
+	}
+	if len(docBlob) > 0 {
+		w.mux.Lock()
+		w.docs[documentPath(path, name)] = bytes.Clone(docBlob)
+		w.mux.Unlock()
+	}
+	return nil
+}
+
+// Generate writes the pages of the written documents with the navigation of the structure. The site
+// index is generated when the structure has no root index document
+func (w *Writer) Generate(root *manifest.Node) error {
+	docs := map[string]*document{}
+	for docPath, source := range w.docs {
+		fm, content, err := yamlfrontmatter.Split(source)
+		if err != nil {
+			return fmt.Errorf("fail to parse the front matter of %s: %w", docPath, err)
+		}
+		docs[docPath] = &document{content: content, title: documentTitle(fm, path.Base(docPath))}
+	}
+	docPaths := make([]string, 0, len(docs))
+	for docPath := range docs {
+		docPaths = append(docPaths, docPath)
+	}
+	slices.Sort(docPaths)
+	for _, docPath := range docPaths {
+		d := docs[docPath]
+		if err := w.writePage(root, docs, linkresolver.HTMLPath(docPath), d.title, d); err != nil {
+			return err
+		}
+	}
+	if _, ok := docs[IndexFileName]; !ok {
+		return w.writePage(root, docs, linkresolver.HTMLPath(IndexFileName), siteTitle, nil)
+	}
+	return nil
+}
+
+// writePage renders a document in the layout, or only the navigation without document
+func (w *Writer) writePage(root *manifest.Node, docs map[string]*document, pagePath string, title string, d *document) error {
+	page := Page{
+		Path:      pagePath,
+		Title:     title,
+		SiteTitle: siteTitle,
+		Root:      strings.Repeat("../", strings.Count(pagePath, "/")),
+		Nav:       navItems(root, docs, pagePath),
+	}
+	if d != nil {
+		page.Content = template.HTML(d.content)
+	}
+	var b bytes.Buffer
+	if err := w.template.Execute(&b, page); err != nil {
+		return fmt.Errorf("fail to execute HTML template for %s: %w", pagePath, err)
+	}
+	return w.writer.Write(path.Base(pagePath), path.Dir(pagePath), b.Bytes(), nil)
+}
+
+// navItems returns the navigation entries of the written documents in a container node. The index documents
+// link their folder entry, and the root index document is the site index linked by the layout
+func navItems(container *manifest.Node, docs map[string]*document, pagePath string) []*NavItem {
+	var items []*NavItem
+	for _, child := range container.Structure {
+		switch child.Type {
+		case "dir":
+			dirPath := path.Clean(child.NodePath())
+			item := &NavItem{Title: frontmatter.NormalizeTitle(child.Name()), Children: navItems(child, docs, pagePath)}
+			if index, ok := docs[documentPath(dirPath, IndexFileName)]; ok {
+				item.Title = index.title
+				item.URL, item.Active = pageURL(pagePath, documentPath(dirPath, IndexFileName))
+			}
+			if item.URL != "" || len(item.Children) > 0 {
+				items = append(items, item)
+			}
+		case "file":
+			docPath := documentPath(child.Path, child.Name())
+			d, ok := docs[docPath]
+			if !ok || child.Name() == IndexFileName {
+				continue
+			}
+			item := &NavItem{Title: d.title}
+			item.URL, item.Active = pageURL(pagePath, docPath)
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// pageURL returns the URL of a document page relative to the current page and if it is the current page
+func pageURL(pagePath string, docPath string) (string, bool) {
+	target := linkresolver.HTMLPath(docPath)
+	rel, err := filepath.Rel(path.Dir("/"+pagePath), "/"+target)
+	if err != nil {
+		return target, false
+	}
+	return filepath.ToSlash(rel), target == pagePath
+}
+
+// documentPath returns the path of a document in the site
+func documentPath(dir string, name string) string {
+	return path.Join(dir, name)
+}
+
+// documentTitle returns the front matter title of a document or its normalized name
+func documentTitle(fm map[string]interface{}, name string) string {
+	if title, ok := fm["title"]; ok && title != nil {
+		return fmt.Sprint(title)
+	}
+	return frontmatter.NormalizeTitle(name)
+}
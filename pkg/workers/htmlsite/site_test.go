@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package htmlsite_test
+
+import (
+	"testing"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/workers/htmlsite"
+	"github.com/gardener/docforge/pkg/writers/writersfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestHTMLSite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "HTML Site Suite")
+}
+
+const navTemplate = `{{ .Title }}|{{ .Root }}{{ template "nav" .Nav }}
+{{ define "nav" }}{{ range . }}[{{ .Title }} {{ .URL }}{{ if .Active }} *{{ end }}{{ template "nav" .Children }}]{{ end }}{{ end }}`
+
+var _ = Describe("HTML site", func() {
+	var (
+		err    error
+		writer *writersfakes.FakeWriter
+		layout string
+		sut    *htmlsite.Writer
+
+		root    *manifest.Node
+		intro   *manifest.Node
+		ops     *manifest.Node
+		index   *manifest.Node
+		install *manifest.Node
+		empty   *manifest.Node
+	)
+
+	BeforeEach(func() {
+		writer = &writersfakes.FakeWriter{}
+		layout = navTemplate
+
+		intro = &manifest.Node{FileType: manifest.FileType{File: "intro.md"}, Type: "file", Path: "."}
+		index = &manifest.Node{FileType: manifest.FileType{File: "index.md"}, Type: "file", Path: "operations"}
+		install = &manifest.Node{FileType: manifest.FileType{File: "install.md"}, Type: "file", Path: "operations"}
+		empty = &manifest.Node{DirType: manifest.DirType{Dir: "empty"}, Type: "dir", Path: "."}
+		ops = &manifest.Node{DirType: manifest.DirType{Dir: "operations", Structure: []*manifest.Node{index, install}}, Type: "dir", Path: "."}
+		root = &manifest.Node{Type: "dir", DirType: manifest.DirType{Structure: []*manifest.Node{intro, ops, empty}}}
+	})
+
+	JustBeforeEach(func() {
+		sut, err = htmlsite.New(writer, layout)
+		if err != nil {
+			return
+		}
+		Expect(sut.Write(intro.Name(), intro.Path, []byte("---\ntitle: Introduction\n---\n<p>See the <a href=\"operations/install.html#steps\">steps</a>.</p>\n"), intro)).To(Succeed())
+		Expect(sut.Write(index.Name(), index.Path, []byte("---\ntitle: Operations\n---\n<h1 id=\"operations\">Operations</h1>\n"), index)).To(Succeed())
+		Expect(sut.Write(install.Name(), install.Path, []byte("<h1 id=\"install\">Install</h1>\n<h2 id=\"steps\">Steps</h2>\n"), install)).To(Succeed())
+		Expect(sut.Write("logo.png", "__resources", []byte("png"), nil)).To(Succeed())
+		err = sut.Generate(root)
+	})
+
+	It("writes other content with the decorated writer", func() {
+		Expect(err).NotTo(HaveOccurred())
+		name, path, content, _ := writer.WriteArgsForCall(0)
+		Expect(name).To(Equal("logo.png"))
+		Expect(path).To(Equal("__resources"))
+		Expect(string(content)).To(Equal("png"))
+	})
+
+	It("writes the pages with the navigation relative to them", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(writer.WriteCallCount()).To(Equal(5))
+		pages := map[string]string{}
+		for i := 1; i < writer.WriteCallCount(); i++ {
+			name, path, content, node := writer.WriteArgsForCall(i)
+			Expect(node).To(BeNil())
+			pages[path+"/"+name] = string(content)
+		}
+		Expect(pages).To(Equal(map[string]string{
+			"./intro.html":            "Introduction|[Introduction intro.html *][Operations operations/index.html[Install operations/install.html]]\n",
+			"operations/index.html":   "Operations|../[Introduction ../intro.html][Operations index.html *[Install install.html]]\n",
+			"operations/install.html": "Install|../[Introduction ../intro.html][Operations index.html[Install install.html *]]\n",
+			"./index.html":            "Documentation|[Introduction intro.html][Operations operations/index.html[Install operations/install.html]]\n",
+		}))
+	})
+
+	Context("with the default template", func() {
+		BeforeEach(func() {
+			layout = htmlsite.DefaultTemplate
+		})
+
+		It("writes the documents in the layout", func() {
+			Expect(err).NotTo(HaveOccurred())
+			name, _, content, _ := writer.WriteArgsForCall(1)
+			Expect(name).To(Equal("intro.html"))
+			Expect(string(content)).To(ContainSubstring("<title>Introduction</title>"))
+			Expect(string(content)).To(ContainSubstring(`<a href="index.html">Documentation</a>`))
+			Expect(string(content)).To(ContainSubstring(`<li><a href="intro.html" class="active">Introduction</a></li>`))
+			Expect(string(content)).To(ContainSubstring("<main>\n<p>See the <a href=\"operations/install.html#steps\">steps</a>.</p>\n\n</main>"))
+		})
+	})
+
+	Context("with an invalid template", func() {
+		BeforeEach(func() {
+			layout = "{{ .Title"
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError(ContainSubstring("invalid HTML template")))
+		})
+	})
+})
@@ -12,7 +12,10 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/gardener/docforge/cmd/docusaurus"
+	"github.com/gardener/docforge/cmd/html"
 	"github.com/gardener/docforge/cmd/hugo"
+	"github.com/gardener/docforge/cmd/mkdocs"
 	"github.com/gardener/docforge/pkg/manifest"
 	"github.com/gardener/docforge/pkg/readers/link"
 	"github.com/gardener/docforge/pkg/readers/repositoryhosts"
@@ -28,6 +31,7 @@ import (
 // Interface represent link resolving interface
 type Interface interface {
 	ResolveLink(destination string, node *manifest.Node, source string) (string, bool, error)
+	ResolveContentLink(destination string, node *manifest.Node, source string) (string, bool, error)
 }
 
 // LinkResolver represents link resolving nessesary objects
@@ -35,10 +39,57 @@ type LinkResolver struct {
 	Repositoryhosts repositoryhosts.Registry
 	SourceToNode    map[string][]*manifest.Node
 	Hugo            hugo.Hugo
+	MkDocs          mkdocs.MkDocs
+	Docusaurus      docusaurus.Docusaurus
+	HTML            html.HTML
 }
 
 // ResolveLink resolves link
 func (l *LinkResolver) ResolveLink(destination string, node *manifest.Node, source string) (string, bool, error) {
+	return l.resolve(destination, node, source, func(destinationNode *manifest.Node) string {
+		return l.NodeLink(destinationNode, node)
+	})
+}
+
+// NodeLink returns the link from the document of the from node to the document of node in the output mode
+func (l *LinkResolver) NodeLink(node *manifest.Node, from *manifest.Node) string {
+	if l.MkDocs.Enabled || l.Docusaurus.Enabled {
+		return RelativeNodeURL(node, from)
+	} else if l.HTML.Enabled {
+		return HTMLPath(RelativeNodeURL(node, from))
+	}
+	return NodeURL(node, l.Hugo)
+}
+
+// PageURL returns the root-relative URL of the node document page in the site of the output mode. MkDocs and Docusaurus
+// serve the pages at directory URLs and the index documents at the URL of their folder, HTML sites at the page files
+func (l *LinkResolver) PageURL(node *manifest.Node) string {
+	if l.HTML.Enabled {
+		return "/" + HTMLPath(path.Clean(node.NodePath()))
+	}
+	if !l.MkDocs.Enabled && !l.Docusaurus.Enabled {
+		return NodeURL(node, l.Hugo)
+	}
+	page := strings.TrimSuffix(path.Clean(node.NodePath()), ".md")
+	if node.Name() == "index.md" || slices.ContainsFunc(l.Hugo.IndexFileNames, func(s string) bool { return strings.EqualFold(node.Name(), s) }) {
+		page = path.Dir(page)
+	}
+	if page == "." {
+		return "/"
+	}
+	return "/" + page + "/"
+}
+
+// ResolveContentLink resolves link like ResolveLink, but the links to nodes are resolved to the path of
+// their content, e.g. `/concepts/apiserver.md`, as the Hugo `ref` and `relref` shortcodes expect
+func (l *LinkResolver) ResolveContentLink(destination string, node *manifest.Node, source string) (string, bool, error) {
+	return l.resolve(destination, node, source, func(destinationNode *manifest.Node) string {
+		return "/" + destinationNode.NodePath()
+	})
+}
+
+// resolve resolves link, the links to nodes are resolved with nodeLink
+func (l *LinkResolver) resolve(destination string, node *manifest.Node, source string, nodeLink func(*manifest.Node) string) (string, bool, error) {
 	escapedEmoji := strings.ReplaceAll(destination, "/:v:/", "/%3Av%3A/")
 	if escapedEmoji != destination {
 		klog.Warningf("escaping : for /:v:/ in link %s for source %s ", destination, source)
@@ -85,11 +136,7 @@ func (l *LinkResolver) ResolveLink(destination string, node *manifest.Node, sour
 		return cmp.Compare(strings.Count(relPathBetweenNodeAndA, "/"), strings.Count(relPathBetweenNodeAndB, "/"))
 	})
 	// construct destination from node path
-	destination = strings.ToLower(destinationNode.NodePath())
-	if l.Hugo.Enabled {
-		destination = strings.ToLower(destinationNode.HugoPrettyPath())
-	}
-	destination = fmt.Sprintf("/%s/", path.Join(l.Hugo.BaseURL, destination))
+	destination = nodeLink(destinationNode)
 	if destinationResource.ForceQuery || destinationResource.RawQuery != "" {
 		destination = fmt.Sprintf("%s?%s", destination, destinationResource.RawQuery)
 	}
@@ -98,3 +145,27 @@ func (l *LinkResolver) ResolveLink(destination string, node *manifest.Node, sour
 	}
 	return destination, true, nil
 }
+
+// NodeURL returns the URL of a node in the documentation bundle
+func NodeURL(node *manifest.Node, hugo hugo.Hugo) string {
+	nodePath := strings.ToLower(node.NodePath())
+	if hugo.Enabled {
+		nodePath = strings.ToLower(node.HugoPrettyPath())
+	}
+	return fmt.Sprintf("/%s/", path.Join(hugo.BaseURL, nodePath))
+}
+
+// RelativeNodeURL returns the path of a node document relative to the folder of the from node document,
+// as MkDocs and Docusaurus resolve links to source files
+func RelativeNodeURL(node *manifest.Node, from *manifest.Node) string {
+	rel, err := filepath.Rel(path.Clean("/"+from.Path), path.Clean("/"+node.NodePath()))
+	if err != nil {
+		return node.NodePath()
+	}
+	return filepath.ToSlash(rel)
+}
+
+// HTMLPath returns the path of a markdown document rendered as HTML
+func HTMLPath(documentPath string) string {
+	return strings.TrimSuffix(documentPath, ".md") + ".html"
+}
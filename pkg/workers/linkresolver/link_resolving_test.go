@@ -13,7 +13,9 @@ import (
 
 	_ "embed"
 
+	"github.com/gardener/docforge/cmd/html"
 	"github.com/gardener/docforge/cmd/hugo"
+	"github.com/gardener/docforge/cmd/mkdocs"
 	"github.com/gardener/docforge/pkg/manifest"
 	"github.com/gardener/docforge/pkg/readers/repositoryhosts"
 	"github.com/gardener/docforge/pkg/readers/repositoryhosts/repositoryhostsfakes"
@@ -130,6 +132,62 @@ var _ = Describe("Document link resolving", func() {
 			Expect(validate).To(Equal(true))
 		})
 
+		It("Resolves content links to the node content path", func() {
+			newLink, validate, err := linkResolver.ResolveContentLink("clickhere#c", node, source)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(newLink).To(Equal("/one/internal/linked.md#c"))
+			Expect(validate).To(Equal(true))
+			newLink, _, err = linkResolver.ResolveContentLink("https://github.com/fake_owner/fake_repo/blob/master/docs/_index.md", node, source)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(newLink).To(Equal("/two/internal/_index.md"))
+		})
+
+		Context("with MkDocs", func() {
+			BeforeEach(func() {
+				linkResolver.Hugo = hugo.Hugo{}
+				linkResolver.MkDocs = mkdocs.MkDocs{Enabled: true}
+			})
+
+			It("Resolves linking to manifest source relative to the document", func() {
+				newLink, validate, err := linkResolver.ResolveLink("clickhere?a=b#c", node, source)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newLink).To(Equal("internal/linked.md?a=b#c"))
+				Expect(validate).To(Equal(true))
+			})
+
+			It("Resolves linking to a document in another folder", func() {
+				newLink, _, err := linkResolver.ResolveLink("https://github.com/fake_owner/fake_repo/blob/master/docs/_index.md", node, source)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newLink).To(Equal("../two/internal/_index.md"))
+			})
+
+			It("Resolves anchor correctly", func() {
+				newLink, _, err := linkResolver.ResolveLink("#anchor", node, source)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newLink).To(Equal("node.md#anchor"))
+			})
+		})
+
+		Context("with HTML", func() {
+			BeforeEach(func() {
+				linkResolver.Hugo = hugo.Hugo{}
+				linkResolver.HTML = html.HTML{Enabled: true}
+			})
+
+			It("Resolves linking to the HTML page relative to the document", func() {
+				newLink, validate, err := linkResolver.ResolveLink("clickhere?a=b#c", node, source)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newLink).To(Equal("internal/linked.html?a=b#c"))
+				Expect(validate).To(Equal(true))
+			})
+
+			It("Resolves linking to a page in another folder", func() {
+				newLink, _, err := linkResolver.ResolveLink("https://github.com/fake_owner/fake_repo/blob/master/docs/_index.md", node, source)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newLink).To(Equal("../two/internal/_index.html"))
+			})
+		})
+
 		It("Escapes /:v:/ correctly", func() {
 			newLink, validate, err := linkResolver.ResolveLink("https://outside_link.com/:v:/one/two", node, source)
 			Expect(err).ToNot(HaveOccurred())
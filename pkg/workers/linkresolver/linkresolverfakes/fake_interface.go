@@ -12,6 +12,23 @@ import (
 )
 
 type FakeInterface struct {
+	ResolveContentLinkStub        func(string, *manifest.Node, string) (string, bool, error)
+	resolveContentLinkMutex       sync.RWMutex
+	resolveContentLinkArgsForCall []struct {
+		arg1 string
+		arg2 *manifest.Node
+		arg3 string
+	}
+	resolveContentLinkReturns struct {
+		result1 string
+		result2 bool
+		result3 error
+	}
+	resolveContentLinkReturnsOnCall map[int]struct {
+		result1 string
+		result2 bool
+		result3 error
+	}
 	ResolveLinkStub        func(string, *manifest.Node, string) (string, bool, error)
 	resolveLinkMutex       sync.RWMutex
 	resolveLinkArgsForCall []struct {
@@ -33,6 +50,75 @@ type FakeInterface struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeInterface) ResolveContentLink(arg1 string, arg2 *manifest.Node, arg3 string) (string, bool, error) {
+	fake.resolveContentLinkMutex.Lock()
+	ret, specificReturn := fake.resolveContentLinkReturnsOnCall[len(fake.resolveContentLinkArgsForCall)]
+	fake.resolveContentLinkArgsForCall = append(fake.resolveContentLinkArgsForCall, struct {
+		arg1 string
+		arg2 *manifest.Node
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ResolveContentLinkStub
+	fakeReturns := fake.resolveContentLinkReturns
+	fake.recordInvocation("ResolveContentLink", []interface{}{arg1, arg2, arg3})
+	fake.resolveContentLinkMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeInterface) ResolveContentLinkCallCount() int {
+	fake.resolveContentLinkMutex.RLock()
+	defer fake.resolveContentLinkMutex.RUnlock()
+	return len(fake.resolveContentLinkArgsForCall)
+}
+
+func (fake *FakeInterface) ResolveContentLinkCalls(stub func(string, *manifest.Node, string) (string, bool, error)) {
+	fake.resolveContentLinkMutex.Lock()
+	defer fake.resolveContentLinkMutex.Unlock()
+	fake.ResolveContentLinkStub = stub
+}
+
+func (fake *FakeInterface) ResolveContentLinkArgsForCall(i int) (string, *manifest.Node, string) {
+	fake.resolveContentLinkMutex.RLock()
+	defer fake.resolveContentLinkMutex.RUnlock()
+	argsForCall := fake.resolveContentLinkArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeInterface) ResolveContentLinkReturns(result1 string, result2 bool, result3 error) {
+	fake.resolveContentLinkMutex.Lock()
+	defer fake.resolveContentLinkMutex.Unlock()
+	fake.ResolveContentLinkStub = nil
+	fake.resolveContentLinkReturns = struct {
+		result1 string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeInterface) ResolveContentLinkReturnsOnCall(i int, result1 string, result2 bool, result3 error) {
+	fake.resolveContentLinkMutex.Lock()
+	defer fake.resolveContentLinkMutex.Unlock()
+	fake.ResolveContentLinkStub = nil
+	if fake.resolveContentLinkReturnsOnCall == nil {
+		fake.resolveContentLinkReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 bool
+			result3 error
+		})
+	}
+	fake.resolveContentLinkReturnsOnCall[i] = struct {
+		result1 string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeInterface) ResolveLink(arg1 string, arg2 *manifest.Node, arg3 string) (string, bool, error) {
 	fake.resolveLinkMutex.Lock()
 	ret, specificReturn := fake.resolveLinkReturnsOnCall[len(fake.resolveLinkArgsForCall)]
@@ -105,6 +191,8 @@ func (fake *FakeInterface) ResolveLinkReturnsOnCall(i int, result1 string, resul
 func (fake *FakeInterface) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.resolveContentLinkMutex.RLock()
+	defer fake.resolveContentLinkMutex.RUnlock()
 	fake.resolveLinkMutex.RLock()
 	defer fake.resolveLinkMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
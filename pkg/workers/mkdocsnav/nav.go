@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mkdocsnav
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/workers/document/frontmatter"
+	"github.com/gardener/docforge/pkg/writers"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ConfigFileName is the name of the MkDocs configuration file
+	ConfigFileName = "mkdocs.yml"
+	// DocsDir is the folder of the documents next to the MkDocs configuration file
+	DocsDir = "docs"
+	// IndexFileName is the name of MkDocs section index documents
+	IndexFileName = "index.md"
+	// defaultSiteName is the site name of configurations that don't define one
+	defaultSiteName = "Documentation"
+)
+
+// Writer generates the `nav` section of the MkDocs configuration from the structure. It decorates the
+// writer of the documents and records their titles as written
+type Writer struct {
+	writer       writers.Writer
+	configWriter writers.Writer
+	baseConfig   []byte
+
+	mux    sync.Mutex
+	titles map[*manifest.Node]string
+}
+
+// New creates a nav Writer decorating writer. The configuration is written with configWriter and
+// keeps the other sections of baseConfig, if any
+func New(writer writers.Writer, configWriter writers.Writer, baseConfig []byte) *Writer {
+	return &Writer{
+		writer:       writer,
+		configWriter: configWriter,
+		baseConfig:   baseConfig,
+		titles:       map[*manifest.Node]string{},
+	}
+}
+
+// Write records the `title` front matter of the written document
+func (w *Writer) Write(name, path string, docBlob []byte, node *manifest.Node) error {
+	if node != nil && node.Type == "file" && len(docBlob) > 0 {
+		w.mux.Lock()
+		w.titles[node] = parseTitle(docBlob)
+		w.mux.Unlock()
+	}
+	return w.writer.Write(name, path, docBlob, node)
+}
+
+// Generate writes the MkDocs configuration with the nav of the written documents in the structure
+func (w *Writer) Generate(root *manifest.Node) error {
+	config := &yaml.Node{Kind: yaml.MappingNode}
+	if len(bytes.TrimSpace(w.baseConfig)) > 0 {
+		var doc yaml.Node
+		if err := yaml.Unmarshal(w.baseConfig, &doc); err != nil {
+			return fmt.Errorf("invalid MkDocs configuration: %w", err)
+		}
+		if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+			return fmt.Errorf("invalid MkDocs configuration: expected a mapping")
+		}
+		config = doc.Content[0]
+	}
+	if mappingValue(config, "site_name") == nil {
+		setMappingValue(config, "site_name", &yaml.Node{Kind: yaml.ScalarNode, Value: defaultSiteName})
+	}
+	nav := &yaml.Node{}
+	if err := nav.Encode(w.nav(root, false)); err != nil {
+		return err
+	}
+	setMappingValue(config, "nav", nav)
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return w.configWriter.Write(ConfigFileName, "", out, nil)
+}
+
+// nav returns the entries of the written documents in a container node. The index document of a section
+// is its first entry, without title, as the section is named after it
+func (w *Writer) nav(container *manifest.Node, section bool) []interface{} {
+	var entries []interface{}
+	for _, child := range container.Structure {
+		switch child.Type {
+		case "dir":
+			children := w.nav(child, true)
+			if len(children) > 0 {
+				entries = append(entries, map[string]interface{}{w.sectionTitle(child): children})
+			}
+		case "file":
+			title, written := w.titles[child]
+			if !written {
+				continue
+			}
+			link := path.Clean(child.NodePath())
+			if child.Name() == IndexFileName && section {
+				entries = append([]interface{}{link}, entries...)
+			} else if title != "" {
+				entries = append(entries, map[string]interface{}{title: link})
+			} else {
+				entries = append(entries, link)
+			}
+		}
+	}
+	return entries
+}
+
+// sectionTitle returns the title of the index document of a dir node or its normalized name
+func (w *Writer) sectionTitle(dir *manifest.Node) string {
+	for _, child := range dir.Structure {
+		if child.Type == "file" && child.Name() == IndexFileName && w.titles[child] != "" {
+			return w.titles[child]
+		}
+	}
+	return frontmatter.NormalizeTitle(dir.Name())
+}
+
+// RenameIndexes renames the first document of each dir node matching one of the indexFileNames, in that order,
+// to the MkDocs section index name. Dir nodes that already have an index document are not changed
+func RenameIndexes(container *manifest.Node, indexFileNames []string) {
+	var files []*manifest.Node
+	hasIndex := false
+	for _, child := range container.Structure {
+		switch child.Type {
+		case "dir":
+			RenameIndexes(child, indexFileNames)
+		case "file":
+			hasIndex = hasIndex || child.Name() == IndexFileName
+			files = append(files, child)
+		}
+	}
+	if hasIndex {
+		return
+	}
+	for _, name := range indexFileNames {
+		for _, file := range files {
+			if strings.EqualFold(file.Name(), name) {
+				file.File = IndexFileName
+				return
+			}
+		}
+	}
+}
+
+// parseTitle returns the `title` front matter of a document or an empty string
+func parseTitle(docBlob []byte) string {
+	if title, ok := frontmatter.Parse(docBlob)["title"]; ok && title != nil {
+		return fmt.Sprint(title)
+	}
+	return ""
+}
+
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func setMappingValue(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+}
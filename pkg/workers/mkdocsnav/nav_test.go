@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mkdocsnav_test
+
+import (
+	"testing"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/workers/mkdocsnav"
+	"github.com/gardener/docforge/pkg/writers/writersfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestMkDocsNav(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "MkDocs Nav Suite")
+}
+
+var _ = Describe("MkDocs nav", func() {
+	var (
+		err          error
+		writer       *writersfakes.FakeWriter
+		configWriter *writersfakes.FakeWriter
+		baseConfig   string
+		sut          *mkdocsnav.Writer
+
+		root    *manifest.Node
+		home    *manifest.Node
+		guides  *manifest.Node
+		readme  *manifest.Node
+		install *manifest.Node
+		setup   *manifest.Node
+		empty   *manifest.Node
+	)
+
+	BeforeEach(func() {
+		writer = &writersfakes.FakeWriter{}
+		configWriter = &writersfakes.FakeWriter{}
+		baseConfig = ""
+
+		home = &manifest.Node{FileType: manifest.FileType{File: "index.md"}, Type: "file", Path: "."}
+		install = &manifest.Node{FileType: manifest.FileType{File: "install.md"}, Type: "file", Path: "guides"}
+		readme = &manifest.Node{FileType: manifest.FileType{File: "README.md"}, Type: "file", Path: "guides"}
+		setup = &manifest.Node{FileType: manifest.FileType{File: "setup.md"}, Type: "file", Path: "guides"}
+		empty = &manifest.Node{DirType: manifest.DirType{Dir: "empty"}, Type: "dir", Path: "guides"}
+		guides = &manifest.Node{DirType: manifest.DirType{Dir: "getting-started", Structure: []*manifest.Node{install, readme, setup, empty}}, Type: "dir", Path: "."}
+		root = &manifest.Node{Type: "dir", DirType: manifest.DirType{Structure: []*manifest.Node{home, guides}}}
+	})
+
+	JustBeforeEach(func() {
+		mkdocsnav.RenameIndexes(root, []string{"readme.md", "index.md"})
+		sut = mkdocsnav.New(writer, configWriter, []byte(baseConfig))
+		Expect(sut.Write(home.Name(), home.Path, []byte("---\ntitle: Home\n---\n# Home\n"), home)).To(Succeed())
+		Expect(sut.Write(install.Name(), install.Path, []byte("# Install\n"), install)).To(Succeed())
+		Expect(sut.Write(readme.Name(), readme.Path, []byte("---\ntitle: Guides\n---\n"), readme)).To(Succeed())
+		err = sut.Generate(root)
+	})
+
+	It("writes the documents with the decorated writer", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(writer.WriteCallCount()).To(Equal(3))
+		name, path, _, node := writer.WriteArgsForCall(2)
+		Expect(name).To(Equal("index.md"))
+		Expect(path).To(Equal("guides"))
+		Expect(node).To(Equal(readme))
+	})
+
+	It("generates the nav of the written documents", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configWriter.WriteCallCount()).To(Equal(1))
+		name, path, content, node := configWriter.WriteArgsForCall(0)
+		Expect(name).To(Equal("mkdocs.yml"))
+		Expect(path).To(Equal(""))
+		Expect(node).To(BeNil())
+		Expect(string(content)).To(Equal("site_name: Documentation\nnav:\n" +
+			"    - Home: index.md\n" +
+			"    - Guides:\n" +
+			"        - guides/index.md\n" +
+			"        - guides/install.md\n"))
+	})
+
+	Context("with a base configuration", func() {
+		BeforeEach(func() {
+			baseConfig = "# site\nsite_name: Gardener\nnav:\n  - old.md\ntheme:\n  name: material\n"
+		})
+
+		It("replaces its nav", func() {
+			Expect(err).NotTo(HaveOccurred())
+			_, _, content, _ := configWriter.WriteArgsForCall(0)
+			Expect(string(content)).To(Equal("# site\nsite_name: Gardener\nnav:\n" +
+				"    - Home: index.md\n" +
+				"    - Guides:\n" +
+				"        - guides/index.md\n" +
+				"        - guides/install.md\n" +
+				"theme:\n    name: material\n"))
+		})
+	})
+
+	Context("with an invalid base configuration", func() {
+		BeforeEach(func() {
+			baseConfig = "- site_name\n"
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError("invalid MkDocs configuration: expected a mapping"))
+			Expect(configWriter.WriteCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("#RenameIndexes", func() {
+		It("keeps an existing index document", func() {
+			Expect(home.Name()).To(Equal("index.md"))
+			Expect(readme.Name()).To(Equal("index.md"))
+			Expect(install.Name()).To(Equal("install.md"))
+		})
+
+		It("uses the section files in order", func() {
+			first := &manifest.Node{FileType: manifest.FileType{File: "index.md"}, Type: "file"}
+			second := &manifest.Node{FileType: manifest.FileType{File: "read.me"}, Type: "file"}
+			dir := &manifest.Node{DirType: manifest.DirType{Dir: "dir", Structure: []*manifest.Node{first}}, Type: "dir"}
+			mkdocsnav.RenameIndexes(&manifest.Node{Type: "dir", DirType: manifest.DirType{Structure: []*manifest.Node{dir, second}}}, []string{"read.me"})
+			Expect(first.Name()).To(Equal("index.md"))
+			Expect(second.Name()).To(Equal("index.md"))
+		})
+	})
+})
@@ -0,0 +1,236 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package searchindex
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/gardener/docforge/pkg/workers/document/markdown"
+	"github.com/yuin/goldmark/ast"
+)
+
+const (
+	// Lunr writes the documents to add to a lunr.js index, referenced by their `url`
+	Lunr = "lunr"
+	// Inverted writes the documents and an index of their terms
+	Inverted = "inverted"
+	// DefaultFileName is the default search index file name
+	DefaultFileName = "search-index.json"
+)
+
+const (
+	// Title is the document title field
+	Title = "title"
+	// Headings is the document headings field
+	Headings = "headings"
+	// Body is the document plain text field
+	Body = "body"
+	// Tags is the document `tags` front matter field
+	Tags = "tags"
+)
+
+// DefaultFields are the indexed fields by default
+var DefaultFields = []string{Title, Headings, Body, Tags}
+
+// Entry is an indexed document
+type Entry struct {
+	URL      string   `json:"url"`
+	Title    string   `json:"title,omitempty"`
+	Headings []string `json:"headings,omitempty"`
+	Body     string   `json:"body,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// Document is a document of an inverted index
+type Document struct {
+	URL   string `json:"url"`
+	Title string `json:"title,omitempty"`
+}
+
+// InvertedIndex maps the terms of the documents to their positions in the documents list
+type InvertedIndex struct {
+	Documents []Document       `json:"documents"`
+	Index     map[string][]int `json:"index"`
+}
+
+// Source is the content of a document source
+type Source struct {
+	Doc     ast.Node
+	Content []byte
+}
+
+// Validate checks the search index format
+func Validate(format string) error {
+	if format != Lunr && format != Inverted {
+		return fmt.Errorf("unsupported search index format %s, must be one of: %s or %s", format, Lunr, Inverted)
+	}
+	return nil
+}
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate -header ../../../license_prefix.txt
+
+// Interface indexes the processed documents
+//
+//counterfeiter:generate . Interface
+type Interface interface {
+	// Add indexes the text of the sources of the document published at url
+	Add(url string, title string, tags []string, sources []Source)
+	// Write writes the search index in the format
+	Write(w io.Writer, format string) error
+}
+
+// Index collects the text of the processed documents
+type Index struct {
+	fields           []string
+	excludedSections []string
+
+	mux     sync.Mutex
+	entries []Entry
+}
+
+// New creates an Index of the fields. The sections with a heading in excludedSections are not indexed
+func New(fields []string, excludedSections []string) (*Index, error) {
+	for _, f := range fields {
+		if !slices.Contains(DefaultFields, f) {
+			return nil, fmt.Errorf("unsupported search index field %s, must be one of: %s", f, strings.Join(DefaultFields, ", "))
+		}
+	}
+	excluded := make([]string, 0, len(excludedSections))
+	for _, s := range excludedSections {
+		excluded = append(excluded, strings.ToLower(strings.TrimSpace(s)))
+	}
+	return &Index{fields: fields, excludedSections: excluded}, nil
+}
+
+// Add indexes the text of the sources of the document published at url
+func (i *Index) Add(url string, title string, tags []string, sources []Source) {
+	entry := Entry{URL: url}
+	if i.indexes(Title) {
+		entry.Title = title
+	}
+	if i.indexes(Tags) {
+		entry.Tags = tags
+	}
+	var headings []string
+	var body strings.Builder
+	for _, s := range sources {
+		headings = append(headings, i.collect(s, &body)...)
+	}
+	if i.indexes(Headings) {
+		entry.Headings = headings
+	}
+	if i.indexes(Body) {
+		entry.Body = strings.Join(strings.Fields(body.String()), " ")
+	}
+	i.mux.Lock()
+	defer i.mux.Unlock()
+	i.entries = append(i.entries, entry)
+}
+
+// collect writes the plain text of a source in body and returns its headings. The sections
+// under excluded headings are skipped up to the next heading of the same or a higher level
+func (i *Index) collect(s Source, body *strings.Builder) []string {
+	var headings []string
+	excludedLevel := 0
+	for n := s.Doc.FirstChild(); n != nil; n = n.NextSibling() {
+		if h, ok := n.(*ast.Heading); ok {
+			if excludedLevel > 0 && h.Level <= excludedLevel {
+				excludedLevel = 0
+			}
+			if excludedLevel > 0 {
+				continue
+			}
+			text := markdown.HeadingText(h, s.Content)
+			if slices.Contains(i.excludedSections, strings.ToLower(text)) {
+				excludedLevel = h.Level
+				continue
+			}
+			headings = append(headings, text)
+			continue
+		}
+		if excludedLevel == 0 {
+			writeText(n, s.Content, body)
+		}
+	}
+	return headings
+}
+
+// writeText writes the plain text of a block node. Code blocks and raw HTML are not indexed
+func writeText(node ast.Node, source []byte, body *strings.Builder) {
+	_ = ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			if n.Type() == ast.TypeBlock {
+				body.WriteString(" ")
+			}
+			return ast.WalkContinue, nil
+		}
+		switch t := n.(type) {
+		case *ast.FencedCodeBlock, *ast.CodeBlock, *ast.HTMLBlock, *ast.RawHTML:
+			return ast.WalkSkipChildren, nil
+		case *ast.Text:
+			body.Write(t.Segment.Value(source))
+			if t.SoftLineBreak() || t.HardLineBreak() {
+				body.WriteString(" ")
+			}
+		case *ast.String:
+			body.Write(t.Value)
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+func (i *Index) indexes(field string) bool {
+	return slices.Contains(i.fields, field)
+}
+
+// Write writes the search index in the format with the documents ordered by URL
+func (i *Index) Write(w io.Writer, format string) error {
+	if err := Validate(format); err != nil {
+		return err
+	}
+	i.mux.Lock()
+	entries := append([]Entry{}, i.entries...)
+	i.mux.Unlock()
+	slices.SortFunc(entries, func(a, b Entry) int {
+		return strings.Compare(a.URL, b.URL)
+	})
+	var out interface{} = entries
+	if format == Inverted {
+		out = invert(entries)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// invert returns the inverted index of the entries terms
+func invert(entries []Entry) InvertedIndex {
+	index := InvertedIndex{Documents: make([]Document, 0, len(entries)), Index: map[string][]int{}}
+	for pos, e := range entries {
+		index.Documents = append(index.Documents, Document{URL: e.URL, Title: e.Title})
+		texts := append(append([]string{e.Title, e.Body}, e.Headings...), e.Tags...)
+		for _, text := range texts {
+			for _, term := range terms(text) {
+				if positions := index.Index[term]; len(positions) == 0 || positions[len(positions)-1] != pos {
+					index.Index[term] = append(positions, pos)
+				}
+			}
+		}
+	}
+	return index
+}
+
+// terms returns the lowercase words of a text
+func terms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package searchindex_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gardener/docforge/pkg/workers/document/markdown"
+	"github.com/gardener/docforge/pkg/workers/searchindex"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestSearchIndex(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Search Index Suite")
+}
+
+func source(content string) searchindex.Source {
+	doc, err := markdown.Parse([]byte(content))
+	Expect(err).NotTo(HaveOccurred())
+	return searchindex.Source{Doc: doc, Content: []byte(content)}
+}
+
+var _ = Describe("Search index", func() {
+	var (
+		err      error
+		fields   []string
+		excluded []string
+		format   string
+		out      bytes.Buffer
+		sut      *searchindex.Index
+	)
+
+	BeforeEach(func() {
+		fields = searchindex.DefaultFields
+		excluded = []string{"Changelog"}
+		format = searchindex.Lunr
+		out.Reset()
+	})
+
+	JustBeforeEach(func() {
+		sut, err = searchindex.New(fields, excluded)
+		if err != nil {
+			return
+		}
+		sut.Add("/docs/usage/", "Usage", nil, []searchindex.Source{
+			source("# Usage\n\nRun the **docforge**\ncommand.\n\n```bash\ndocforge -f manifest.yaml\n```\n\n## Changelog\n\nFixed bugs.\n\n### Details\n\nNone.\n\n## Flags\n\n- `--hugo` <b>flag</b>\n"),
+		})
+		sut.Add("/docs/install/", "Install", []string{"setup", "go"}, []searchindex.Source{
+			source("# Install\n\nGo install.\n"),
+			source("## Build\n\nMake.\n"),
+		})
+		err = sut.Write(&out, format)
+	})
+
+	It("writes the documents text ordered by URL", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out.String()).To(Equal(`[
+  {
+    "url": "/docs/install/",
+    "title": "Install",
+    "headings": [
+      "Install",
+      "Build"
+    ],
+    "body": "Go install. Make.",
+    "tags": [
+      "setup",
+      "go"
+    ]
+  },
+  {
+    "url": "/docs/usage/",
+    "title": "Usage",
+    "headings": [
+      "Usage",
+      "Flags"
+    ],
+    "body": "Run the docforge command. --hugo flag"
+  }
+]
+`))
+	})
+
+	Context("with fields", func() {
+		BeforeEach(func() {
+			fields = []string{searchindex.Title}
+		})
+
+		It("writes only the fields", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.String()).To(Equal("[\n  {\n    \"url\": \"/docs/install/\",\n    \"title\": \"Install\"\n  },\n  {\n    \"url\": \"/docs/usage/\",\n    \"title\": \"Usage\"\n  }\n]\n"))
+		})
+	})
+
+	Context("with an unsupported field", func() {
+		BeforeEach(func() {
+			fields = []string{"author"}
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError("unsupported search index field author, must be one of: title, headings, body, tags"))
+		})
+	})
+
+	Context("as an inverted index", func() {
+		BeforeEach(func() {
+			fields = []string{searchindex.Title, searchindex.Tags}
+			format = searchindex.Inverted
+		})
+
+		It("maps the terms to the documents", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.String()).To(Equal(`{
+  "documents": [
+    {
+      "url": "/docs/install/",
+      "title": "Install"
+    },
+    {
+      "url": "/docs/usage/",
+      "title": "Usage"
+    }
+  ],
+  "index": {
+    "go": [
+      0
+    ],
+    "install": [
+      0
+    ],
+    "setup": [
+      0
+    ],
+    "usage": [
+      1
+    ]
+  }
+}
+`))
+		})
+	})
+
+	Context("with an unsupported format", func() {
+		BeforeEach(func() {
+			format = "xml"
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError("unsupported search index format xml, must be one of: lunr or inverted"))
+		})
+	})
+})
@@ -0,0 +1,266 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sectionindex
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/workers/document/frontmatter"
+	"github.com/gardener/docforge/pkg/workers/document/markdown"
+	"github.com/gardener/docforge/pkg/workers/linkresolver"
+	"github.com/gardener/docforge/pkg/writers"
+	"github.com/hashicorp/go-multierror"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultTemplate lists the section pages with their titles and descriptions
+const DefaultTemplate = `{{ range .Children }}
+- [{{ .Title }}]({{ .Link }}){{ with .Description }} - {{ . }}{{ end }}
+{{- end }}
+`
+
+// Section is the data of section index templates
+type Section struct {
+	// Node is the dir node of the section
+	Node *manifest.Node
+	// Title is the section title
+	Title string
+	// Children are the section pages in manifest order
+	Children []Page
+}
+
+// Page is a page listed in a section index
+type Page struct {
+	// Node is the file or dir node of the page
+	Node *manifest.Node
+	// Title is the page title
+	Title string
+	// Description is the `description` front matter of the page
+	Description string
+	// Link is the URL of the page in the documentation bundle
+	Link string
+}
+
+// Writer generates section index documents for dir nodes. It decorates the
+// writer of the documents and records their front matter as written
+type Writer struct {
+	writer   writers.Writer
+	template *template.Template
+	append   bool
+	links    *linkresolver.LinkResolver
+
+	mux          sync.Mutex
+	frontmatters map[*manifest.Node]map[string]interface{}
+	indexes      map[*manifest.Node][]byte
+}
+
+// New creates a section index Writer decorating writer. With appendToIndex
+// the pages list is also appended to existing index files. The pages are
+// linked as links resolves them for the output mode
+func New(writer writers.Writer, indexTemplate string, appendToIndex bool, links *linkresolver.LinkResolver) (*Writer, error) {
+	tmpl, err := template.New("section index").Parse(indexTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid section index template: %w", err)
+	}
+	return &Writer{
+		writer:       writer,
+		template:     tmpl,
+		append:       appendToIndex,
+		links:        links,
+		frontmatters: map[*manifest.Node]map[string]interface{}{},
+		indexes:      map[*manifest.Node][]byte{},
+	}, nil
+}
+
+// Write records the written document front matter. Index documents are held back when their pages list is appended
+func (w *Writer) Write(name, path string, docBlob []byte, node *manifest.Node) error {
+	if node == nil || node.Type != "file" {
+		return w.writer.Write(name, path, docBlob, node)
+	}
+	w.mux.Lock()
+	w.frontmatters[node] = frontmatter.Parse(docBlob)
+	if w.append && w.isIndex(node) {
+		w.indexes[node] = bytes.Clone(docBlob)
+		w.mux.Unlock()
+		return nil
+	}
+	w.mux.Unlock()
+	return w.writer.Write(name, path, docBlob, node)
+}
+
+// Generate writes the section index documents of the dir nodes in the structure
+func (w *Writer) Generate(root *manifest.Node) error {
+	var errs *multierror.Error
+	for _, child := range root.Structure {
+		if child.Type == "dir" {
+			errs = multierror.Append(errs, w.generate(child))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+func (w *Writer) generate(dir *manifest.Node) error {
+	var errs *multierror.Error
+	section := Section{Node: dir, Title: frontmatter.NormalizeTitle(dir.Name())}
+	var index *manifest.Node
+	for _, child := range dir.Structure {
+		switch {
+		case child.Type == "dir":
+			errs = multierror.Append(errs, w.generate(child))
+			section.Children = append(section.Children, w.page(child, dir, w.sectionTitle(child)))
+		case index == nil && w.isIndex(child):
+			index = child
+			if title, ok := w.frontmatter(child)["title"]; ok {
+				section.Title = fmt.Sprint(title)
+			}
+		case child.Type == "file":
+			fm, written := w.frontmatters[child]
+			if !written {
+				continue
+			}
+			page := w.page(child, dir, frontmatter.NormalizeTitle(child.Name()))
+			if title, ok := fm["title"]; ok {
+				page.Title = fmt.Sprint(title)
+			}
+			if description, ok := fm["description"]; ok {
+				page.Description = fmt.Sprint(description)
+			}
+			section.Children = append(section.Children, page)
+		}
+	}
+	if index != nil && !w.append {
+		return errs.ErrorOrNil()
+	}
+	var b bytes.Buffer
+	if err := w.template.Execute(&b, section); err != nil {
+		return multierror.Append(errs, fmt.Errorf("fail to execute section index template for %s: %w", dir.NodePath(), err))
+	}
+	if index != nil && len(w.indexes[index]) > 0 {
+		list, err := w.render(b.Bytes())
+		if err != nil {
+			return multierror.Append(errs, err)
+		}
+		content := append(w.indexes[index], list...)
+		return multierror.Append(errs, w.writer.Write(index.Name(), index.Path, content, index)).ErrorOrNil()
+	}
+	name, frontmatter := "index.md", dir.Frontmatter
+	if w.links.Hugo.Enabled {
+		name = "_index.md"
+	}
+	if index != nil {
+		// index file without content
+		name, frontmatter = index.Name(), index.Frontmatter
+	}
+	content, err := w.indexDocument(frontmatter, section.Title, b.Bytes())
+	if err != nil {
+		return multierror.Append(errs, err)
+	}
+	return multierror.Append(errs, w.writer.Write(name, dir.NodePath(), content, nil)).ErrorOrNil()
+}
+
+// indexDocument builds a generated index document. Hugo index documents have front matter, others a title heading.
+// HTML index documents have both and are rendered as HTML
+func (w *Writer) indexDocument(frontmatter map[string]interface{}, title string, list []byte) ([]byte, error) {
+	var b bytes.Buffer
+	if !w.links.Hugo.Enabled && !w.links.HTML.Enabled {
+		fmt.Fprintf(&b, "# %s\n", title)
+		b.Write(list)
+		return b.Bytes(), nil
+	}
+	fm := map[string]interface{}{}
+	for k, v := range frontmatter {
+		fm[k] = v
+	}
+	if _, ok := fm["title"]; !ok {
+		fm["title"] = title
+	}
+	out, err := yaml.Marshal(fm)
+	if err != nil {
+		return nil, err
+	}
+	b.WriteString("---\n")
+	b.Write(out)
+	b.WriteString("---\n")
+	if w.links.HTML.Enabled {
+		fmt.Fprintf(&b, "# %s\n", title)
+	}
+	b.Write(list)
+	return w.render(b.Bytes())
+}
+
+// render renders markdown as HTML for the HTML site
+func (w *Writer) render(content []byte) ([]byte, error) {
+	if !w.links.HTML.Enabled {
+		return content, nil
+	}
+	doc, err := markdown.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	if err = markdown.NewHTMLRenderer().Render(&b, content, doc); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// page returns the page of a node listed in the section index of dir. Outside Hugo the dir pages are their index documents
+func (w *Writer) page(node *manifest.Node, dir *manifest.Node, title string) Page {
+	target := node
+	if node.Type == "dir" && !w.links.Hugo.Enabled {
+		target = w.index(node)
+	}
+	return Page{Node: node, Title: title, Link: w.links.NodeLink(target, &manifest.Node{Path: dir.NodePath()})}
+}
+
+// index returns the index file of a dir node, or the generated index document
+func (w *Writer) index(dir *manifest.Node) *manifest.Node {
+	for _, child := range dir.Structure {
+		if w.isIndex(child) {
+			return child
+		}
+	}
+	return &manifest.Node{FileType: manifest.FileType{File: "index.md"}, Type: "file", Path: dir.NodePath()}
+}
+
+// sectionTitle returns the title of the section index of a dir node
+func (w *Writer) sectionTitle(dir *manifest.Node) string {
+	for _, child := range dir.Structure {
+		if w.isIndex(child) {
+			if title, ok := w.frontmatter(child)["title"]; ok {
+				return fmt.Sprint(title)
+			}
+		}
+	}
+	return frontmatter.NormalizeTitle(dir.Name())
+}
+
+func (w *Writer) frontmatter(node *manifest.Node) map[string]interface{} {
+	if fm := w.frontmatters[node]; fm != nil {
+		return fm
+	}
+	return node.Frontmatter
+}
+
+// isIndex returns true if the node is an index file as defined by the section files
+func (w *Writer) isIndex(node *manifest.Node) bool {
+	if node.Type != "file" {
+		return false
+	}
+	if node.Name() == "_index.md" {
+		return true
+	}
+	for _, s := range w.links.Hugo.IndexFileNames {
+		if strings.EqualFold(node.Name(), s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sectionindex_test
+
+import (
+	"testing"
+
+	"github.com/gardener/docforge/cmd/docusaurus"
+	"github.com/gardener/docforge/cmd/html"
+	"github.com/gardener/docforge/cmd/hugo"
+	"github.com/gardener/docforge/cmd/mkdocs"
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/workers/linkresolver"
+	"github.com/gardener/docforge/pkg/workers/sectionindex"
+	"github.com/gardener/docforge/pkg/writers/writersfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestSectionIndex(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Section Index Suite")
+}
+
+type writtenDoc struct {
+	node    *manifest.Node
+	content string
+}
+
+var _ = Describe("Section index", func() {
+	var (
+		err     error
+		writer  *writersfakes.FakeWriter
+		sut     *sectionindex.Writer
+		links   *linkresolver.LinkResolver
+		tmpl    string
+		extend  bool
+		written []writtenDoc
+
+		root    *manifest.Node
+		docs    *manifest.Node
+		guides  *manifest.Node
+		intro   *manifest.Node
+		install *manifest.Node
+		skipped *manifest.Node
+		setup   *manifest.Node
+	)
+
+	BeforeEach(func() {
+		writer = &writersfakes.FakeWriter{}
+		links = &linkresolver.LinkResolver{Hugo: hugo.Hugo{Enabled: true, IndexFileNames: []string{"readme.md"}}}
+		tmpl = sectionindex.DefaultTemplate
+		extend = false
+
+		intro = &manifest.Node{FileType: manifest.FileType{File: "intro.md"}, Type: "file", Path: "docs"}
+		install = &manifest.Node{FileType: manifest.FileType{File: "install.md"}, Type: "file", Path: "docs"}
+		skipped = &manifest.Node{FileType: manifest.FileType{File: "skipped.md"}, Type: "file", Path: "docs"}
+		setup = &manifest.Node{FileType: manifest.FileType{File: "setup.md"}, Type: "file", Path: "docs/guides"}
+		guides = &manifest.Node{DirType: manifest.DirType{Dir: "guides", Structure: []*manifest.Node{setup}}, Type: "dir", Path: "docs"}
+		docs = &manifest.Node{DirType: manifest.DirType{Dir: "docs", Structure: []*manifest.Node{install, guides, skipped, intro}}, Type: "dir", Path: "."}
+		root = &manifest.Node{Type: "dir", DirType: manifest.DirType{Structure: []*manifest.Node{docs}}}
+		written = []writtenDoc{
+			{intro, "---\ntitle: Introduction\ndescription: What it is\n---\n# Intro\n"},
+			{install, "# Install\n"},
+			{setup, "---\ntitle: Setup\n---\n"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		sut, err = sectionindex.New(writer, tmpl, extend, links)
+		Expect(err).NotTo(HaveOccurred())
+		for _, w := range written {
+			Expect(sut.Write(w.node.Name(), w.node.Path, []byte(w.content), w.node)).To(Succeed())
+		}
+		err = sut.Generate(root)
+	})
+
+	It("generates index documents listing the child pages in manifest order", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(writer.WriteCallCount()).To(Equal(5))
+		name, path, content, node := writer.WriteArgsForCall(3)
+		Expect(name).To(Equal("_index.md"))
+		Expect(path).To(Equal("docs/guides"))
+		Expect(node).To(BeNil())
+		Expect(string(content)).To(Equal("---\ntitle: Guides\n---\n\n- [Setup](/docs/guides/setup/)\n"))
+		name, path, content, _ = writer.WriteArgsForCall(4)
+		Expect(name).To(Equal("_index.md"))
+		Expect(path).To(Equal("docs"))
+		Expect(string(content)).To(Equal("---\ntitle: Docs\n---\n\n- [Install](/docs/install/)\n- [Guides](/docs/guides/)\n- [Introduction](/docs/intro/) - What it is\n"))
+	})
+
+	When("the template is invalid", func() {
+		It("fails", func() {
+			_, err = sectionindex.New(writer, "{{ .Children", false, links)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("the dir has an index file", func() {
+		var index *manifest.Node
+
+		BeforeEach(func() {
+			index = &manifest.Node{FileType: manifest.FileType{File: "README.md"}, Type: "file", Path: "docs"}
+			docs.Structure = append(docs.Structure, index)
+			written = append(written, writtenDoc{index, "---\ntitle: Documentation\n---\n# Docs\n"})
+		})
+
+		It("keeps the index file", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(writer.WriteCallCount()).To(Equal(5))
+			name, _, _, node := writer.WriteArgsForCall(3)
+			Expect(name).To(Equal("README.md"))
+			Expect(node).To(Equal(index))
+			name, path, _, _ := writer.WriteArgsForCall(4)
+			Expect(name).To(Equal("_index.md"))
+			Expect(path).To(Equal("docs/guides"))
+		})
+
+		When("the pages list is appended", func() {
+			BeforeEach(func() {
+				extend = true
+				tmpl = "{{ .Title }}:{{ range .Children }}\n* {{ .Title }}{{ end }}\n"
+			})
+
+			It("appends the pages list to the index file", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(writer.WriteCallCount()).To(Equal(5))
+				name, path, content, node := writer.WriteArgsForCall(4)
+				Expect(name).To(Equal("README.md"))
+				Expect(path).To(Equal("docs"))
+				Expect(node).To(Equal(index))
+				Expect(string(content)).To(Equal("---\ntitle: Documentation\n---\n# Docs\nDocumentation:\n* Install\n* Guides\n* Introduction\n"))
+			})
+		})
+	})
+
+	When("hugo is disabled", func() {
+		BeforeEach(func() {
+			links = &linkresolver.LinkResolver{}
+		})
+
+		It("generates index documents with a title heading", func() {
+			Expect(err).NotTo(HaveOccurred())
+			name, path, content, _ := writer.WriteArgsForCall(4)
+			Expect(name).To(Equal("index.md"))
+			Expect(path).To(Equal("docs"))
+			Expect(string(content)).To(Equal("# Docs\n\n- [Install](/docs/install.md/)\n- [Guides](/docs/guides/index.md/)\n- [Introduction](/docs/intro.md/) - What it is\n"))
+		})
+	})
+	When("mkdocs is enabled", func() {
+		BeforeEach(func() {
+			links = &linkresolver.LinkResolver{MkDocs: mkdocs.MkDocs{Enabled: true}}
+		})
+
+		It("links the documents relative to the index documents", func() {
+			Expect(err).NotTo(HaveOccurred())
+			_, _, content, _ := writer.WriteArgsForCall(3)
+			Expect(string(content)).To(Equal("# Guides\n\n- [Setup](setup.md)\n"))
+			_, _, content, _ = writer.WriteArgsForCall(4)
+			Expect(string(content)).To(Equal("# Docs\n\n- [Install](install.md)\n- [Guides](guides/index.md)\n- [Introduction](intro.md) - What it is\n"))
+		})
+	})
+
+	When("docusaurus is enabled", func() {
+		BeforeEach(func() {
+			links = &linkresolver.LinkResolver{Docusaurus: docusaurus.Docusaurus{Enabled: true}, Hugo: hugo.Hugo{IndexFileNames: []string{"readme.md"}}}
+			guides.Structure = append(guides.Structure, &manifest.Node{FileType: manifest.FileType{File: "README.md"}, Type: "file", Path: "docs/guides"})
+		})
+
+		It("links the documents and the folder index files relative to the index documents", func() {
+			Expect(err).NotTo(HaveOccurred())
+			name, path, content, _ := writer.WriteArgsForCall(3)
+			Expect(name).To(Equal("index.md"))
+			Expect(path).To(Equal("docs"))
+			Expect(string(content)).To(Equal("# Docs\n\n- [Install](install.md)\n- [Guides](guides/README.md)\n- [Introduction](intro.md) - What it is\n"))
+		})
+	})
+
+	When("html is enabled", func() {
+		BeforeEach(func() {
+			links = &linkresolver.LinkResolver{HTML: html.HTML{Enabled: true}}
+		})
+
+		It("renders the index documents as HTML linking the pages", func() {
+			Expect(err).NotTo(HaveOccurred())
+			name, path, content, _ := writer.WriteArgsForCall(4)
+			Expect(name).To(Equal("index.md"))
+			Expect(path).To(Equal("docs"))
+			Expect(string(content)).To(Equal("---\ntitle: Docs\n---\n<h1>Docs</h1>\n<ul>\n<li><a href=\"install.html\">Install</a></li>\n<li><a href=\"guides/index.html\">Guides</a></li>\n<li><a href=\"intro.html\">Introduction</a> - What it is</li>\n</ul>\n"))
+		})
+	})
+})
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package singledocument
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/writers"
+)
+
+// Resources decorates the writer of the downloaded resources and holds back their content, so
+// that they can be inlined whatever the resources are written to
+type Resources struct {
+	writer writers.Writer
+
+	mux     sync.Mutex
+	content map[string][]byte
+}
+
+// NewResources creates a Resources decorating writer
+func NewResources(writer writers.Writer) *Resources {
+	return &Resources{
+		writer:  writer,
+		content: map[string][]byte{},
+	}
+}
+
+// Write records the content of the resource and writes it with the decorated writer
+func (r *Resources) Write(name, path string, resourceContent []byte, node *manifest.Node) error {
+	r.mux.Lock()
+	r.content[name] = resourceContent
+	r.mux.Unlock()
+	return r.writer.Write(name, path, resourceContent, node)
+}
+
+// Read returns the content of a written resource
+func (r *Resources) Read(name string) ([]byte, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	content, ok := r.content[name]
+	if !ok {
+		return nil, fmt.Errorf("resource %s was not downloaded", name)
+	}
+	return content, nil
+}
@@ -0,0 +1,243 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package singledocument
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/gardener/docforge/cmd/hugo"
+	yamlfrontmatter "github.com/gardener/docforge/pkg/frontmatter"
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/workers/document/frontmatter"
+	"github.com/gardener/docforge/pkg/workers/document/markdown"
+	"github.com/gardener/docforge/pkg/workers/linkresolver"
+	"github.com/gardener/docforge/pkg/writers"
+	"github.com/yuin/goldmark/ast"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// ReferenceImages links the images to the downloaded resources
+	ReferenceImages = "reference"
+	// InlineImages embeds the images as data URIs
+	InlineImages = "inline"
+)
+
+// separates the path segments and the extension of the document anchors
+var pathSeparators = strings.NewReplacer("/", " ", ".", " ")
+
+// ReadResource returns the content of a downloaded resource
+type ReadResource func(name string) ([]byte, error)
+
+// ValidateImages returns an error if the images mode is not supported
+func ValidateImages(images string) error {
+	if images != ReferenceImages && images != InlineImages {
+		return fmt.Errorf("unsupported single document images %s, must be one of: reference or inline", images)
+	}
+	return nil
+}
+
+// Writer concatenates the documents of the structure in a single document. It decorates the writer
+// of the single document and holds back the documents as written
+type Writer struct {
+	writer        writers.Writer
+	name          string
+	resourcesRoot string
+	hugo          hugo.Hugo
+	readResource  ReadResource
+
+	mux  sync.Mutex
+	docs map[*manifest.Node][]byte
+}
+
+// part is a section of the single document, either a dir heading or a document
+type part struct {
+	node   *manifest.Node
+	offset int
+	source []byte
+	doc    ast.Node
+	// ids maps the heading anchors of the part to the anchors in the single document
+	ids map[string]string
+}
+
+// New creates a single document Writer writing the document name with writer. The images are
+// embedded with the content returned by readResource, or linked to the resources if it is nil
+func New(writer writers.Writer, name string, resourcesRoot string, hugo hugo.Hugo, readResource ReadResource) *Writer {
+	return &Writer{
+		writer:        writer,
+		name:          name,
+		resourcesRoot: resourcesRoot,
+		hugo:          hugo,
+		readResource:  readResource,
+		docs:          map[*manifest.Node][]byte{},
+	}
+}
+
+// Write holds back the written documents. Other content is written as it is
+func (w *Writer) Write(name, path string, docBlob []byte, node *manifest.Node) error {
+	if node == nil || node.Type != "file" {
+		return w.writer.Write(name, path, docBlob, node)
+	}
+	if len(docBlob) > 0 {
+		w.mux.Lock()
+		w.docs[node] = bytes.Clone(docBlob)
+		w.mux.Unlock()
+	}
+	return nil
+}
+
+// Generate writes the single document with the written documents in the structure order. The headings
+// are shifted by the depth of the documents and dir nodes are headings. Links to the documents are
+// rewritten to their anchors in the single document
+func (w *Writer) Generate(root *manifest.Node) error {
+	parts, err := w.parts(root, 0)
+	if err != nil {
+		return err
+	}
+	byNode := map[*manifest.Node]*part{}
+	urls := map[string]*manifest.Node{}
+	ids := map[string]int{}
+	for _, p := range parts {
+		local := markdown.Headings(p.doc, p.source, map[string]int{})
+		for i, h := range markdown.Headings(p.doc, p.source, ids) {
+			p.ids[local[i].ID] = h.ID
+		}
+		if p.node != nil {
+			byNode[p.node] = p
+			urls[linkresolver.NodeURL(p.node, w.hugo)] = p.node
+		}
+	}
+	var b bytes.Buffer
+	for i, p := range parts {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		if p.node == nil {
+			b.Write(p.source)
+			continue
+		}
+		fmt.Fprintf(&b, "<a id=\"%s\"></a>\n\n", w.docAnchor(p.node))
+		resolve := func(dest string, isEmbeddable bool) (string, error) {
+			return w.resolveLink(dest, isEmbeddable, p, byNode, urls)
+		}
+		rnd := markdown.NewLinkModifierRenderer(markdown.WithLinkResolver(resolve), markdown.WithHeadingOffset(p.offset))
+		if err := rnd.Render(&b, p.source, p.doc); err != nil {
+			return fmt.Errorf("fail to render %s in the single document: %w", p.node.NodePath(), err)
+		}
+	}
+	return w.writer.Write(w.name, "", b.Bytes(), nil)
+}
+
+// parts returns the parts of the written documents in a container node at a depth. A dir node with
+// written documents is a heading of its level followed by its parts
+func (w *Writer) parts(container *manifest.Node, depth int) ([]*part, error) {
+	var parts []*part
+	for _, child := range container.Structure {
+		switch child.Type {
+		case "dir":
+			children, err := w.parts(child, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			if len(children) == 0 {
+				continue
+			}
+			source := []byte(strings.Repeat("#", min(depth+1, 6)) + " " + frontmatter.NormalizeTitle(child.Name()) + "\n")
+			heading, err := newPart(nil, 0, source)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(append(parts, heading), children...)
+		case "file":
+			docBlob, written := w.docs[child]
+			if !written {
+				continue
+			}
+			p, err := newPart(child, depth, documentSource(docBlob))
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, p)
+		}
+	}
+	return parts, nil
+}
+
+func newPart(node *manifest.Node, offset int, source []byte) (*part, error) {
+	doc, err := markdown.Parse(source)
+	if err != nil {
+		return nil, err
+	}
+	return &part{node: node, offset: offset, source: source, doc: doc, ids: map[string]string{}}, nil
+}
+
+// documentSource returns the content of a written document without its front matter. The front matter
+// title is a heading of documents that don't start with one
+func documentSource(docBlob []byte) []byte {
+	fm, body, _ := yamlfrontmatter.Split(docBlob)
+	title := ""
+	if t, ok := fm["title"]; ok && t != nil {
+		title = fmt.Sprint(t)
+	}
+	body = bytes.TrimLeft(body, "\r\n")
+	if title == "" || bytes.HasPrefix(body, []byte("# ")) {
+		return body
+	}
+	return append([]byte("# "+title+"\n\n"), body...)
+}
+
+// resolveLink rewrites the links to documents of the single document to their anchors and the links to resources
+func (w *Writer) resolveLink(dest string, isEmbeddable bool, p *part, byNode map[*manifest.Node]*part, urls map[string]*manifest.Node) (string, error) {
+	base, fragment, _ := strings.Cut(dest, "#")
+	base, _, _ = strings.Cut(base, "?")
+	if base == "" {
+		if id, ok := p.ids[fragment]; ok {
+			return "#" + id, nil
+		}
+		return dest, nil
+	}
+	if target, ok := urls[base]; ok {
+		if fragment == "" {
+			return "#" + w.docAnchor(target), nil
+		}
+		if id, ok := byNode[target].ids[fragment]; ok {
+			return "#" + id, nil
+		}
+		return "#" + fragment, nil
+	}
+	resourcesPrefix := "/" + path.Join(w.hugo.BaseURL, w.resourcesRoot) + "/"
+	if !strings.HasPrefix(base, resourcesPrefix) {
+		return dest, nil
+	}
+	name := strings.TrimPrefix(base, resourcesPrefix)
+	if !isEmbeddable || w.readResource == nil {
+		return path.Join(w.resourcesRoot, name), nil
+	}
+	content, err := w.readResource(name)
+	if err != nil {
+		klog.Warningf("linking resource %s instead of inlining it: %v", name, err)
+		return path.Join(w.resourcesRoot, name), nil
+	}
+	mediaType := mime.TypeByExtension(path.Ext(name))
+	if mediaType == "" {
+		mediaType = "application/octet-stream"
+	}
+	return "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(content), nil
+}
+
+// docAnchor returns the anchor of a document in the single document, the slug of its path in the slug mode of the output
+func (w *Writer) docAnchor(node *manifest.Node) string {
+	mode := markdown.GitHubSlugs
+	if w.hugo.Enabled {
+		mode = markdown.HugoSlugs
+	}
+	return "doc-" + markdown.Slug(pathSeparators.Replace(path.Clean(node.NodePath())), mode)
+}
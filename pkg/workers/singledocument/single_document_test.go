@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2023 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package singledocument_test
+
+import (
+	"testing"
+
+	"github.com/gardener/docforge/cmd/hugo"
+	"github.com/gardener/docforge/pkg/manifest"
+	"github.com/gardener/docforge/pkg/workers/singledocument"
+	"github.com/gardener/docforge/pkg/writers/writersfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestSingleDocument(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Single Document Suite")
+}
+
+var _ = Describe("Single document", func() {
+	var (
+		err          error
+		writer       *writersfakes.FakeWriter
+		readResource singledocument.ReadResource
+		sut          *singledocument.Writer
+
+		root    *manifest.Node
+		intro   *manifest.Node
+		ops     *manifest.Node
+		install *manifest.Node
+		upgrade *manifest.Node
+		empty   *manifest.Node
+	)
+
+	BeforeEach(func() {
+		writer = &writersfakes.FakeWriter{}
+		readResource = nil
+
+		intro = &manifest.Node{FileType: manifest.FileType{File: "intro.md"}, Type: "file", Path: "."}
+		install = &manifest.Node{FileType: manifest.FileType{File: "install.md"}, Type: "file", Path: "operations"}
+		upgrade = &manifest.Node{FileType: manifest.FileType{File: "upgrade.md"}, Type: "file", Path: "operations"}
+		empty = &manifest.Node{DirType: manifest.DirType{Dir: "empty"}, Type: "dir", Path: "operations"}
+		ops = &manifest.Node{DirType: manifest.DirType{Dir: "operations", Structure: []*manifest.Node{install, empty, upgrade}}, Type: "dir", Path: "."}
+		root = &manifest.Node{Type: "dir", DirType: manifest.DirType{Structure: []*manifest.Node{intro, ops}}}
+	})
+
+	JustBeforeEach(func() {
+		sut = singledocument.New(writer, "guide.md", "__resources", hugo.Hugo{BaseURL: "docs"}, readResource)
+		Expect(sut.Write(intro.Name(), intro.Path, []byte("---\ntitle: Introduction\n---\n\nRead the [installation](/docs/operations/install.md/#steps).\n\n## Steps\n\n![logo](/docs/__resources/logo_1a2b3c.png)\n"), intro)).To(Succeed())
+		Expect(sut.Write(install.Name(), install.Path, []byte("# Install\n\n## Steps\n\nSee the [upgrade](/docs/operations/upgrade.md/) and [below](#steps).\n"), install)).To(Succeed())
+		Expect(sut.Write(upgrade.Name(), upgrade.Path, []byte("# Upgrade\n\nUpgrade [it](https://example.com).\n"), upgrade)).To(Succeed())
+		err = sut.Generate(root)
+	})
+
+	It("concatenates the documents with headings shifted by depth", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(writer.WriteCallCount()).To(Equal(1))
+		name, path, content, node := writer.WriteArgsForCall(0)
+		Expect(name).To(Equal("guide.md"))
+		Expect(path).To(Equal(""))
+		Expect(node).To(BeNil())
+		Expect(string(content)).To(Equal("<a id=\"doc-intro-md\"></a>\n\n" +
+			"# Introduction\n\nRead the [installation](#steps-1).\n\n## Steps\n\n![logo](__resources/logo_1a2b3c.png)\n" +
+			"\n# Operations\n" +
+			"\n<a id=\"doc-operations-install-md\"></a>\n\n" +
+			"## Install\n\n### Steps\n\nSee the [upgrade](#doc-operations-upgrade-md) and [below](#steps-1).\n" +
+			"\n<a id=\"doc-operations-upgrade-md\"></a>\n\n" +
+			"## Upgrade\n\nUpgrade [it](https://example.com).\n"))
+	})
+
+	Context("with inline images", func() {
+		var (
+			resourcesWriter *writersfakes.FakeWriter
+			resources       *singledocument.Resources
+		)
+
+		BeforeEach(func() {
+			resourcesWriter = &writersfakes.FakeWriter{}
+			resources = singledocument.NewResources(resourcesWriter)
+			readResource = resources.Read
+		})
+
+		It("links the images that were not downloaded", func() {
+			Expect(err).NotTo(HaveOccurred())
+			_, _, content, _ := writer.WriteArgsForCall(0)
+			Expect(string(content)).To(ContainSubstring("![logo](__resources/logo_1a2b3c.png)"))
+		})
+
+		When("the images are downloaded", func() {
+			BeforeEach(func() {
+				Expect(resources.Write("logo_1a2b3c.png", "", []byte("png"), nil)).To(Succeed())
+			})
+
+			It("embeds the images as data URIs", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resourcesWriter.WriteCallCount()).To(Equal(1))
+				_, _, content, _ := writer.WriteArgsForCall(0)
+				Expect(string(content)).To(ContainSubstring("![logo](data:image/png;base64,cG5n)"))
+			})
+		})
+	})
+
+	It("validates the images mode", func() {
+		Expect(singledocument.ValidateImages("inline")).To(Succeed())
+		Expect(singledocument.ValidateImages("link")).To(MatchError("unsupported single document images link, must be one of: reference or inline"))
+	})
+})